@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -17,10 +18,13 @@ import (
 
 	"github.com/namru/movie-recommend/internal/config"
 	"github.com/namru/movie-recommend/internal/handler"
+	"github.com/namru/movie-recommend/internal/provider"
 	"github.com/namru/movie-recommend/internal/repository/postgres"
 	"github.com/namru/movie-recommend/internal/repository/redis"
 	"github.com/namru/movie-recommend/internal/router"
 	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/blobstore"
+	"github.com/namru/movie-recommend/pkg/httpclient"
 	"github.com/namru/movie-recommend/pkg/logger"
 )
 
@@ -74,22 +78,94 @@ func main() {
 	userRepo := postgres.NewUserRepo(pool)
 	movieRepo := postgres.NewMovieRepo(pool)
 	watchlistRepo := postgres.NewWatchlistRepo(pool)
+	listRepo := postgres.NewListRepo(pool)
+	tagRepo := postgres.NewTagRepo(pool)
+	watchEventRepo := postgres.NewWatchEventRepo(pool)
 	ratingRepo := postgres.NewRatingRepo(pool)
+	episodeRepo := postgres.NewEpisodeRepo(pool)
+	activityRepo := postgres.NewActivityRepo(pool)
+	auditRepo := postgres.NewAuditRepo(pool)
 	cacheRepo := redis.NewCacheRepo(rdb)
+	suggestRepo := redis.NewSuggestRepo(rdb)
+
+	// ---------- Movie Provider ----------
+	movieProvider, err := provider.NewFromConfig(cfg, cacheRepo, zapLogger)
+	if err != nil {
+		zapLogger.Fatal("failed to create movie provider", zap.Error(err))
+	}
+	zapLogger.Info("using movie provider", zap.String("provider", movieProvider.Name()))
 
 	// ---------- Services ----------
 	authService := service.NewAuthService(userRepo, &cfg.JWT, zapLogger)
-	movieService := service.NewMovieService(movieRepo, cacheRepo, cfg, zapLogger)
-	watchlistService := service.NewWatchlistService(watchlistRepo, movieService, zapLogger)
-	ratingService := service.NewRatingService(ratingRepo, movieService, zapLogger)
+	autocompleteService := service.NewAutocompleteService(suggestRepo, movieRepo, cacheRepo, zapLogger)
+	movieService := service.NewMovieService(movieRepo, cacheRepo, movieProvider, autocompleteService, cfg, zapLogger)
+	statsService := service.NewStatsService(ratingRepo, watchlistRepo, cacheRepo, cfg.Cache.StatsTTL, zapLogger)
+	listService := service.NewListService(listRepo, watchlistRepo, statsService, zapLogger)
+	historyService := service.NewHistoryService(watchEventRepo, ratingRepo, movieService, zapLogger)
+	watchlistService := service.NewWatchlistService(watchlistRepo, listService, movieService, statsService, historyService, zapLogger)
+	tagService := service.NewTagService(tagRepo, watchlistRepo, ratingRepo, zapLogger)
+	ratingService := service.NewRatingService(ratingRepo, movieService, statsService, zapLogger)
+	posterStore, err := blobstore.NewLocal(cfg.Poster.StoreDir)
+	if err != nil {
+		zapLogger.Fatal("failed to open poster store", zap.Error(err))
+	}
+	posterClient := httpclient.New(httpclient.Config{
+		Timeout:          cfg.Outbound.Timeout,
+		MaxRetries:       cfg.Outbound.MaxRetries,
+		BaseBackoff:      cfg.Outbound.BaseBackoff,
+		MaxBackoff:       cfg.Outbound.MaxBackoff,
+		BreakerThreshold: cfg.Outbound.BreakerThreshold,
+		BreakerCooldown:  cfg.Outbound.BreakerCooldown,
+	})
+	posterService := service.NewPosterService(movieRepo, posterStore, posterClient, &cfg.Poster, zapLogger)
+	seriesService := service.NewSeriesService(episodeRepo, watchlistRepo, movieService, statsService, historyService, zapLogger)
 	recService := service.NewRecommendationService(ratingRepo, movieRepo, movieService, zapLogger)
+	curationService := service.NewCurationService(movieRepo, auditRepo, statsService, autocompleteService, zapLogger)
+	trendingService := service.NewTrendingService(activityRepo, movieRepo, cacheRepo, &cfg.Trending, zapLogger)
+
+	// ---------- Background Workers ----------
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	if cfg.Refresh.Enabled {
+		refreshWorker := service.NewRefreshWorker(movieRepo, cacheRepo, movieProvider, autocompleteService, &cfg.Refresh, zapLogger)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			refreshWorker.Run(workerCtx)
+		}()
+	}
+	if cfg.Trending.Enabled {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			trendingService.Run(workerCtx)
+		}()
+	}
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		autocompleteService.EnsureBuilt(workerCtx)
+	}()
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
 
 	// ---------- Handlers ----------
 	authHandler := handler.NewAuthHandler(authService)
-	movieHandler := handler.NewMovieHandler(movieService)
+	movieHandler := handler.NewMovieHandler(movieService, statsService, autocompleteService)
 	watchlistHandler := handler.NewWatchlistHandler(watchlistService)
 	ratingHandler := handler.NewRatingHandler(ratingService)
 	recHandler := handler.NewRecommendationHandler(recService)
+	adminHandler := handler.NewAdminHandler(movieService, autocompleteService)
+	seriesHandler := handler.NewSeriesHandler(seriesService)
+	posterHandler := handler.NewPosterHandler(posterService, int(cfg.Poster.MaxAge.Seconds()))
+	trendingHandler := handler.NewTrendingHandler(trendingService)
+	curationHandler := handler.NewCurationHandler(curationService)
+	listHandler := handler.NewListHandler(listService)
+	tagHandler := handler.NewTagHandler(tagService)
+	historyHandler := handler.NewHistoryHandler(historyService)
 
 	// ---------- Router ----------
 	r := router.Setup(
@@ -100,6 +176,14 @@ func main() {
 		watchlistHandler,
 		ratingHandler,
 		recHandler,
+		adminHandler,
+		seriesHandler,
+		posterHandler,
+		trendingHandler,
+		curationHandler,
+		listHandler,
+		tagHandler,
+		historyHandler,
 	)
 
 	// ---------- Server ----------
@@ -134,5 +218,12 @@ func main() {
 		zapLogger.Fatal("server forced to shutdown", zap.Error(err))
 	}
 
+	stopWorkers()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		zapLogger.Warn("background workers did not stop in time")
+	}
+
 	zapLogger.Info("server stopped gracefully")
 }
@@ -0,0 +1,160 @@
+// Command importer loads local IMDb dataset files into the movie catalog.
+//
+// Usage:
+//
+//	go run ./cmd/importer -dir ./data/imdb -min-votes 1000
+//
+// The directory must contain title.basics.tsv.gz, title.ratings.tsv.gz,
+// title.principals.tsv.gz and name.basics.tsv.gz. Re-running after an
+// interruption resumes from the checkpoint file; pass -reset to start over.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	goRedis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/config"
+	"github.com/namru/movie-recommend/internal/importer"
+	"github.com/namru/movie-recommend/internal/repository/postgres"
+	"github.com/namru/movie-recommend/internal/repository/redis"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/logger"
+)
+
+func main() {
+	// Exiting only here lets run's deferred cleanup (closing the pool,
+	// flushing the logger) happen even when the import fails.
+	if err := run(); err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+}
+
+func run() error {
+	dir := flag.String("dir", ".", "directory containing the IMDb .tsv.gz files")
+	types := flag.String("types", "movie", "comma-separated IMDb titleType values to import (movie, tvMovie, tvSeries, tvMiniSeries)")
+	minVotes := flag.Int("min-votes", 0, "skip titles with fewer IMDb votes")
+	batchSize := flag.Int("batch", 5000, "movies per COPY batch")
+	dryRun := flag.Bool("dry-run", false, "parse and report without writing to the database")
+	checkpoint := flag.String("checkpoint", "", "resume checkpoint file (default <dir>/.imdb-import.checkpoint)")
+	reset := flag.Bool("reset", false, "ignore and remove an existing checkpoint")
+	flag.Parse()
+
+	// ---------- Config ----------
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// ---------- Logger ----------
+	zapLogger := logger.New(cfg.Server.GinMode)
+	defer zapLogger.Sync()
+
+	if *batchSize <= 0 {
+		return errors.New("batch size must be positive")
+	}
+	if *checkpoint == "" {
+		*checkpoint = filepath.Join(*dir, ".imdb-import.checkpoint")
+	}
+	if *reset {
+		if err := os.Remove(*checkpoint); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove checkpoint: %w", err)
+		}
+	}
+
+	opts := importer.Options{
+		Dir:            *dir,
+		MinVotes:       *minVotes,
+		BatchSize:      *batchSize,
+		DryRun:         *dryRun,
+		CheckpointFile: *checkpoint,
+	}
+	for _, t := range strings.Split(*types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			opts.Types = append(opts.Types, t)
+		}
+	}
+
+	// Stop between batches on Ctrl+C; the checkpoint lets the next run resume.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// ---------- PostgreSQL ----------
+	var imp *importer.Importer
+	var movieRepo *postgres.MovieRepo
+	if *dryRun {
+		imp = importer.New(nil, opts, zapLogger)
+	} else {
+		pool, err := pgxpool.New(ctx, cfg.Database.DSN())
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer pool.Close()
+
+		if err := pool.Ping(ctx); err != nil {
+			return fmt.Errorf("failed to ping database: %w", err)
+		}
+		movieRepo = postgres.NewMovieRepo(pool)
+		imp = importer.New(movieRepo, opts, zapLogger)
+	}
+
+	stats, err := imp.Run(ctx)
+	fields := []zap.Field{
+		zap.Int("candidates", stats.Candidates),
+		zap.Int("skipped", stats.Skipped),
+		zap.Int("inserted", stats.Inserted),
+		zap.Int("batches", stats.Batches),
+		zap.Bool("dry_run", *dryRun),
+	}
+	if !*dryRun && stats.Inserted > 0 {
+		reindexTitles(ctx, cfg, movieRepo, zapLogger)
+	}
+	if err != nil {
+		zapLogger.Error("import stopped", append(fields, zap.Error(err))...)
+		return err
+	}
+	zapLogger.Info("import finished", fields...)
+	return nil
+}
+
+// reindexTitles brings the autocomplete index up to date with the imported
+// titles by rebuilding it. If that is not possible now (interrupted run,
+// rebuild already running, Redis errors) the index is marked unbuilt so the
+// API rebuilds it on its next start. Failures never fail the import.
+func reindexTitles(ctx context.Context, cfg *config.Config, movieRepo *postgres.MovieRepo, log *zap.Logger) {
+	rdb := goRedis.NewClient(&goRedis.Options{
+		Addr:     cfg.Redis.Addr(),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer rdb.Close()
+
+	cacheRepo := redis.NewCacheRepo(rdb)
+	autocomplete := service.NewAutocompleteService(redis.NewSuggestRepo(rdb), movieRepo, cacheRepo, log)
+
+	if ctx.Err() == nil {
+		log.Info("rebuilding autocomplete index")
+		err := autocomplete.Rebuild(ctx)
+		if err == nil {
+			return
+		}
+		log.Warn("autocomplete rebuild failed", zap.Error(err))
+	}
+
+	if err := autocomplete.Invalidate(context.WithoutCancel(ctx)); err != nil {
+		log.Warn("failed to mark autocomplete index stale; rebuild it via the admin API", zap.Error(err))
+		return
+	}
+	log.Info("autocomplete index marked stale; the API rebuilds it on next start")
+}
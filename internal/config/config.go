@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -14,7 +17,13 @@ type Config struct {
 	Redis    RedisConfig
 	JWT      JWTConfig
 	OMDB     OMDBConfig
+	TMDB     TMDBConfig
+	Provider ProviderConfig
+	Outbound OutboundConfig
 	Cache    CacheConfig
+	Refresh  RefreshConfig
+	Poster   PosterConfig
+	Trending TrendingConfig
 }
 
 type ServerConfig struct {
@@ -44,13 +53,64 @@ type JWTConfig struct {
 }
 
 type OMDBConfig struct {
-	APIKey  string
-	BaseURL string
+	APIKeys    []string // rotated when one runs out of daily quota
+	DailyLimit int      // requests allowed per key per UTC day
+	BaseURL    string
+}
+
+type TMDBConfig struct {
+	APIKey       string
+	BaseURL      string
+	ImageBaseURL string
+}
+
+// ProviderConfig selects the external movie metadata sources.
+type ProviderConfig struct {
+	Name      string        // primary provider: "omdb" or "tmdb"
+	Fallbacks []string      // providers tried, in order, when the primary fails
+	Cooldown  time.Duration // how long a failed provider is skipped
+}
+
+// OutboundConfig tunes retries and circuit breaking for calls to external APIs.
+type OutboundConfig struct {
+	Timeout          time.Duration
+	MaxRetries       int
+	BaseBackoff      time.Duration
+	MaxBackoff       time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
 type CacheConfig struct {
-	SearchTTL time.Duration
-	MovieTTL  time.Duration
+	SearchTTL    time.Duration
+	MovieTTL     time.Duration
+	MovieLock    bool          // coalesce movie lookups across instances via Redis
+	MovieLockTTL time.Duration // lock lifetime, also the max time a waiter blocks
+	StatsTTL     time.Duration // community stats; invalidated early on rating/watchlist changes
+}
+
+// RefreshConfig controls the background worker that re-fetches stale movies.
+type RefreshConfig struct {
+	Enabled     bool
+	MaxAge      time.Duration // movies last refreshed before this are stale
+	Interval    time.Duration // time between batches
+	BatchSize   int
+	DailyBudget int // provider requests the worker may spend per UTC day
+}
+
+// TrendingConfig controls the job that materializes the trending and popular
+// rankings into Redis.
+type TrendingConfig struct {
+	Enabled  bool
+	Interval time.Duration // time between recomputations
+	Size     int           // movies kept per ranking and window
+}
+
+// PosterConfig controls the poster image proxy.
+type PosterConfig struct {
+	StoreDir string        // local blob store directory
+	Widths   []int         // allowed resize widths, ascending
+	MaxAge   time.Duration // Cache-Control max-age sent to clients
 }
 
 // DSN returns the PostgreSQL connection string.
@@ -100,12 +160,51 @@ func Load() (*Config, error) {
 			ExpiryHours: getIntOrDefault("JWT_EXPIRY_HOURS", 24),
 		},
 		OMDB: OMDBConfig{
-			APIKey:  viper.GetString("OMDB_API_KEY"),
-			BaseURL: getStringOrDefault("OMDB_BASE_URL", "http://www.omdbapi.com"),
+			APIKeys:    getListOrDefault("OMDB_API_KEYS", getListOrDefault("OMDB_API_KEY", nil)),
+			DailyLimit: getIntOrDefault("OMDB_DAILY_LIMIT", 1000),
+			BaseURL:    getStringOrDefault("OMDB_BASE_URL", "http://www.omdbapi.com"),
+		},
+		TMDB: TMDBConfig{
+			APIKey:       viper.GetString("TMDB_API_KEY"),
+			BaseURL:      getStringOrDefault("TMDB_BASE_URL", "https://api.themoviedb.org/3"),
+			ImageBaseURL: getStringOrDefault("TMDB_IMAGE_BASE_URL", "https://image.tmdb.org/t/p/w500"),
+		},
+		Provider: ProviderConfig{
+			Name:      getStringOrDefault("MOVIE_PROVIDER", "omdb"),
+			Fallbacks: getListOrDefault("MOVIE_PROVIDER_FALLBACKS", nil),
+			Cooldown:  time.Duration(getIntOrDefault("MOVIE_PROVIDER_COOLDOWN", 60)) * time.Second,
+		},
+		Outbound: OutboundConfig{
+			Timeout:          time.Duration(getIntOrDefault("HTTP_TIMEOUT", 10)) * time.Second,
+			MaxRetries:       getCountOrDefault("HTTP_MAX_RETRIES", 2),
+			BaseBackoff:      time.Duration(getIntOrDefault("HTTP_BACKOFF_MS", 200)) * time.Millisecond,
+			MaxBackoff:       time.Duration(getIntOrDefault("HTTP_MAX_BACKOFF_MS", 2000)) * time.Millisecond,
+			BreakerThreshold: getCountOrDefault("HTTP_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  time.Duration(getIntOrDefault("HTTP_BREAKER_COOLDOWN", 30)) * time.Second,
 		},
 		Cache: CacheConfig{
-			SearchTTL: time.Duration(getIntOrDefault("CACHE_SEARCH_TTL", 86400)) * time.Second,
-			MovieTTL:  time.Duration(getIntOrDefault("CACHE_MOVIE_TTL", 604800)) * time.Second,
+			SearchTTL:    time.Duration(getIntOrDefault("CACHE_SEARCH_TTL", 86400)) * time.Second,
+			MovieTTL:     time.Duration(getIntOrDefault("CACHE_MOVIE_TTL", 604800)) * time.Second,
+			MovieLock:    viper.GetBool("CACHE_MOVIE_LOCK"),
+			MovieLockTTL: time.Duration(getIntOrDefault("CACHE_MOVIE_LOCK_TTL", 15)) * time.Second,
+			StatsTTL:     time.Duration(getIntOrDefault("CACHE_STATS_TTL", 600)) * time.Second,
+		},
+		Refresh: RefreshConfig{
+			Enabled:     viper.GetBool("REFRESH_ENABLED"),
+			MaxAge:      time.Duration(getIntOrDefault("REFRESH_MAX_AGE_HOURS", 720)) * time.Hour,
+			Interval:    time.Duration(getIntOrDefault("REFRESH_INTERVAL_MINUTES", 10)) * time.Minute,
+			BatchSize:   getIntOrDefault("REFRESH_BATCH_SIZE", 20),
+			DailyBudget: getIntOrDefault("REFRESH_DAILY_BUDGET", 200),
+		},
+		Poster: PosterConfig{
+			StoreDir: getStringOrDefault("POSTER_STORE_DIR", "./data/posters"),
+			Widths:   getIntListOrDefault("POSTER_WIDTHS", []int{150, 300, 600}),
+			MaxAge:   time.Duration(getIntOrDefault("POSTER_CACHE_MAX_AGE", 86400)) * time.Second,
+		},
+		Trending: TrendingConfig{
+			Enabled:  getBoolOrDefault("TRENDING_ENABLED", true),
+			Interval: time.Duration(getIntOrDefault("TRENDING_INTERVAL_MINUTES", 15)) * time.Minute,
+			Size:     getIntOrDefault("TRENDING_SIZE", 100),
 		},
 	}
 
@@ -120,6 +219,44 @@ func getStringOrDefault(key, defaultVal string) string {
 	return val
 }
 
+// getListOrDefault reads a comma-separated list, trimming blanks.
+func getListOrDefault(key string, defaultVal []string) []string {
+	var list []string
+	for _, item := range strings.Split(viper.GetString(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	if len(list) == 0 {
+		return defaultVal
+	}
+	return list
+}
+
+// getIntListOrDefault reads a comma-separated list of positive integers,
+// returned in ascending order.
+func getIntListOrDefault(key string, defaultVal []int) []int {
+	var list []int
+	for _, item := range getListOrDefault(key, nil) {
+		n, err := strconv.Atoi(item)
+		if err == nil && n > 0 {
+			list = append(list, n)
+		}
+	}
+	if len(list) == 0 {
+		return defaultVal
+	}
+	sort.Ints(list)
+	return list
+}
+
+func getBoolOrDefault(key string, defaultVal bool) bool {
+	if viper.GetString(key) == "" {
+		return defaultVal
+	}
+	return viper.GetBool(key)
+}
+
 func getIntOrDefault(key string, defaultVal int) int {
 	val := viper.GetInt(key)
 	if val == 0 {
@@ -127,3 +264,18 @@ func getIntOrDefault(key string, defaultVal int) int {
 	}
 	return val
 }
+
+// getCountOrDefault reads a non-negative integer for which 0 is meaningful
+// (usually "disabled"). Only an unset, blank or malformed value falls back to
+// the default.
+func getCountOrDefault(key string, defaultVal int) int {
+	raw := strings.TrimSpace(viper.GetString(key))
+	if raw == "" {
+		return defaultVal
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return defaultVal
+	}
+	return n
+}
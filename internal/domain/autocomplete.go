@@ -0,0 +1,23 @@
+package domain
+
+// TitleSuggestion is one autocomplete entry. Rank orders suggestions under
+// the same prefix; it is not exposed to clients.
+type TitleSuggestion struct {
+	ImdbID string  `json:"imdb_id"`
+	Title  string  `json:"title"`
+	Year   string  `json:"year"`
+	Type   string  `json:"type"`
+	Rank   float64 `json:"-"`
+}
+
+// SuggestionEntry is a suggestion with the prefixes it is filed under and
+// the rank it has under each.
+type SuggestionEntry struct {
+	Suggestion TitleSuggestion
+	Prefixes   map[string]float64
+}
+
+// AutocompleteRequest holds query params for title autocomplete.
+type AutocompleteRequest struct {
+	Query string `form:"q" validate:"required,max=100"`
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovieEditRequest is an admin correction to a stored movie. Only the fields
+// present are changed, and each changed field is locked against refreshes.
+type MovieEditRequest struct {
+	Title          *string `json:"title" validate:"omitempty,min=1,max=255"`
+	Year           *string `json:"year" validate:"omitempty,max=10"`
+	Genre          *string `json:"genre" validate:"omitempty,max=255"`
+	Director       *string `json:"director" validate:"omitempty,max=255"`
+	Actors         *string `json:"actors"`
+	Plot           *string `json:"plot"`
+	PosterURL      *string `json:"poster_url" validate:"omitempty,url"`
+	ImdbRating     *string `json:"imdb_rating" validate:"omitempty,max=10"`
+	Rated          *string `json:"rated" validate:"omitempty,max=20"`
+	Released       *string `json:"released" validate:"omitempty,datetime=2006-01-02"`
+	RuntimeMinutes *int    `json:"runtime_minutes" validate:"omitempty,min=1"`
+	Writer         *string `json:"writer"`
+	Language       *string `json:"language" validate:"omitempty,max=255"`
+	Country        *string `json:"country" validate:"omitempty,max=255"`
+	Awards         *string `json:"awards"`
+	Type           *string `json:"type" validate:"omitempty,oneof=movie series episode"`
+	TotalSeasons   *int    `json:"total_seasons" validate:"omitempty,min=1"`
+}
+
+// MovieLocksRequest replaces the set of fields locked against refreshes.
+type MovieLocksRequest struct {
+	Fields []string `json:"fields" validate:"dive,oneof=title year genre director actors plot poster_url imdb_rating rated released runtime_minutes writer language country awards type total_seasons"`
+}
+
+// MovieHiddenRequest hides a movie from search and recommendations, or
+// shows it again.
+type MovieHiddenRequest struct {
+	Hidden *bool `json:"hidden" validate:"required"`
+}
+
+// MovieMergeRequest names the movie that a duplicate is merged into.
+type MovieMergeRequest struct {
+	IntoImdbID string `json:"into_imdb_id" validate:"required,max=20"`
+}
+
+// MergeResult reports how a duplicate's user data was re-pointed. Ratings
+// are dropped when the user already rated the surviving movie, and watchlist
+// entries when it is already on the same list. EpisodeProgressMerged counts
+// watched episodes of dropped entries carried over to the surviving entry,
+// and TagsMerged the tags of dropped ratings and entries added to the
+// surviving ones.
+type MergeResult struct {
+	Movie                 *Movie `json:"movie"`
+	MergedImdbID          string `json:"merged_imdb_id"`
+	RatingsMoved          int    `json:"ratings_moved"`
+	RatingsDropped        int    `json:"ratings_dropped"`
+	WatchlistsMoved       int    `json:"watchlists_moved"`
+	WatchlistsDropped     int    `json:"watchlists_dropped"`
+	EpisodeProgressMerged int    `json:"episode_progress_merged"`
+	TagsMerged            int    `json:"tags_merged"`
+}
+
+// AuditChanges returns the merge counts as recorded in the audit log.
+func (r *MergeResult) AuditChanges() map[string]interface{} {
+	return map[string]interface{}{
+		"merged_imdb_id":          r.MergedImdbID,
+		"ratings_moved":           r.RatingsMoved,
+		"ratings_dropped":         r.RatingsDropped,
+		"watchlists_moved":        r.WatchlistsMoved,
+		"watchlists_dropped":      r.WatchlistsDropped,
+		"episode_progress_merged": r.EpisodeProgressMerged,
+		"tags_merged":             r.TagsMerged,
+	}
+}
+
+// MovieEdit is an admin edit as persisted by MovieRepository.SaveEdit: the
+// edited metadata and locked fields of Movie, optionally its re-derived
+// genre and people links, and the audit entry, all in one transaction.
+// Refreshes use it through SaveRefresh, without locks or audit entry.
+type MovieEdit struct {
+	Movie       *Movie
+	SaveCredits bool
+	Genres      []string
+	Credits     []Credit
+	Audit       *MovieAuditEntry
+}
+
+// AuditAction names a kind of curation change.
+type AuditAction string
+
+const (
+	AuditEdit   AuditAction = "edit"
+	AuditLock   AuditAction = "lock"
+	AuditHide   AuditAction = "hide"
+	AuditUnhide AuditAction = "unhide"
+	AuditMerge  AuditAction = "merge"
+)
+
+// FieldChange is the before and after value of one edited field.
+type FieldChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// MovieAuditEntry records one admin change to a movie.
+type MovieAuditEntry struct {
+	ID        uuid.UUID              `json:"id" db:"id"`
+	MovieID   uuid.UUID              `json:"movie_id" db:"movie_id"`
+	ImdbID    string                 `json:"imdb_id" db:"imdb_id"`
+	AdminID   *uuid.UUID             `json:"admin_id" db:"admin_id"`
+	Action    AuditAction            `json:"action" db:"action"`
+	Changes   map[string]interface{} `json:"changes" db:"changes"`
+	CreatedAt time.Time              `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WatchEvent records one viewing of a movie. A user can have any number of
+// events per movie; WatchNumber counts them in watched_at order, so anything
+// above 1 is a rewatch. Rating is the score given for this viewing, which
+// may differ from the user's current rating.
+type WatchEvent struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	MovieID     uuid.UUID  `json:"movie_id" db:"movie_id"`
+	WatchlistID *uuid.UUID `json:"watchlist_id,omitempty" db:"watchlist_id"`
+	WatchedAt   time.Time  `json:"watched_at" db:"watched_at"`
+	Rating      *int       `json:"rating,omitempty" db:"rating"`
+	Venue       string     `json:"venue" db:"venue"`
+	Companions  []string   `json:"companions" db:"companions"`
+	WatchNumber int        `json:"watch_number"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	Movie       *Movie     `json:"movie,omitempty"` // joined data
+}
+
+// CreateWatchEventRequest logs a viewing. WatchedAt defaults to now.
+type CreateWatchEventRequest struct {
+	ImdbID     string     `json:"imdb_id" validate:"required"`
+	WatchedAt  *time.Time `json:"watched_at"`
+	Rating     *int       `json:"rating" validate:"omitempty,gte=1,lte=10"`
+	Venue      string     `json:"venue" validate:"max=100"`
+	Companions []string   `json:"companions" validate:"max=20,dive,required,max=100"`
+}
+
+// UpdateWatchEventRequest changes a logged viewing. Only the fields present
+// are changed; a rating of 0 clears it.
+type UpdateWatchEventRequest struct {
+	WatchedAt  *time.Time `json:"watched_at"`
+	Rating     *int       `json:"rating" validate:"omitempty,gte=0,lte=10"`
+	Venue      *string    `json:"venue" validate:"omitempty,max=100"`
+	Companions []string   `json:"companions" validate:"omitempty,max=20,dive,required,max=100"`
+}
+
+// WatchHistoryRequest holds the query params for the watch timeline. From
+// and To are inclusive dates; ImdbID limits it to one movie's viewings.
+type WatchHistoryRequest struct {
+	ImdbID string    `form:"imdb_id" validate:"max=20"`
+	From   time.Time `form:"from" time_format:"2006-01-02"`
+	To     time.Time `form:"to" time_format:"2006-01-02"`
+	Limit  int       `form:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor string    `form:"cursor"`
+}
+
+// WatchEventCursor is the decoded keyset position of a timeline page.
+type WatchEventCursor struct {
+	WatchedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// WatchHistoryQuery is a validated timeline request as passed to the
+// repository. Before is exclusive.
+type WatchHistoryQuery struct {
+	UserID uuid.UUID
+	ImdbID string
+	From   time.Time
+	Before time.Time
+	After  *WatchEventCursor
+	Limit  int
+}
+
+// WatchHistoryPage is one page of the watch timeline, newest first.
+type WatchHistoryPage struct {
+	Events     []WatchEvent `json:"events"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+	Next       string       `json:"next,omitempty"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultListName is the name given to a user's default list when it is
+// created.
+const DefaultListName = "Watchlist"
+
+// UserList is a named watchlist. Every user has one default list, which backs
+// the original /watchlist routes and cannot be deleted.
+type UserList struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	Name       string    `json:"name" db:"name"`
+	Position   int       `json:"position" db:"position"`
+	IsDefault  bool      `json:"is_default" db:"is_default"`
+	EntryCount int       `json:"entry_count"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateListRequest is the input for creating a list.
+type CreateListRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// RenameListRequest is the input for renaming a list.
+type RenameListRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// ReorderListsRequest gives every one of the user's lists in its new order.
+type ReorderListsRequest struct {
+	ListIDs []uuid.UUID `json:"list_ids" validate:"required,min=1"`
+}
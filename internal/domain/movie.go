@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,17 +9,215 @@ import (
 
 // Movie represents a movie (cached from OMDb).
 type Movie struct {
-	ID         uuid.UUID `json:"id" db:"id"`
-	ImdbID     string    `json:"imdb_id" db:"imdb_id"`
-	Title      string    `json:"title" db:"title"`
-	Year       string    `json:"year" db:"year"`
-	Genre      string    `json:"genre" db:"genre"`
-	Director   string    `json:"director" db:"director"`
-	Actors     string    `json:"actors" db:"actors"`
-	Plot       string    `json:"plot" db:"plot"`
-	PosterURL  string    `json:"poster_url" db:"poster_url"`
-	ImdbRating string    `json:"imdb_rating" db:"imdb_rating"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	ID             uuid.UUID  `json:"id" db:"id"`
+	ImdbID         string     `json:"imdb_id" db:"imdb_id"`
+	Title          string     `json:"title" db:"title"`
+	Year           string     `json:"year" db:"year"`
+	Genre          string     `json:"genre" db:"genre"`
+	Director       string     `json:"director" db:"director"`
+	Actors         string     `json:"actors" db:"actors"`
+	Plot           string     `json:"plot" db:"plot"`
+	PosterURL      string     `json:"poster_url" db:"poster_url"`
+	ImdbRating     string     `json:"imdb_rating" db:"imdb_rating"`
+	Rated          string     `json:"rated" db:"rated"`
+	Released       *time.Time `json:"released,omitempty" db:"released"`
+	RuntimeMinutes *int       `json:"runtime_minutes,omitempty" db:"runtime_minutes"`
+	Writer         string     `json:"writer" db:"writer"`
+	Language       string     `json:"language" db:"language"`
+	Country        string     `json:"country" db:"country"`
+	Awards         string     `json:"awards" db:"awards"`
+	Type           string     `json:"type" db:"type"`
+	ReleaseYear    *int       `json:"release_year,omitempty" db:"release_year"`
+	ImdbScore      *float64   `json:"imdb_score,omitempty" db:"imdb_score"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	RefreshedAt    time.Time  `json:"refreshed_at" db:"refreshed_at"`
+	TotalSeasons   *int       `json:"total_seasons,omitempty" db:"total_seasons"` // series only
+	Hidden         bool       `json:"hidden,omitempty" db:"hidden"`               // excluded from search and recommendations
+	LockedFields   []string   `json:"locked_fields,omitempty" db:"locked_fields"` // not overwritten by refreshes
+}
+
+// MovieStats aggregates community activity for a movie.
+type MovieStats struct {
+	AverageScore float64                 `json:"average_score"`
+	RatingCount  int                     `json:"rating_count"`
+	Histogram    map[int]int             `json:"histogram"` // score (1-10) -> count
+	Watchlist    map[WatchlistStatus]int `json:"watchlist"` // status -> number of users
+}
+
+// MovieDetail is a movie enriched with community stats and, when known, the
+// requesting user's own rating and watchlist status.
+type MovieDetail struct {
+	*Movie
+	Community       MovieStats       `json:"community"`
+	UserRating      *Rating          `json:"user_rating,omitempty"`
+	WatchlistStatus *WatchlistStatus `json:"watchlist_status,omitempty"`
+}
+
+// MovieSearchRequest holds the query parameters accepted by movie search.
+type MovieSearchRequest struct {
+	Query string `form:"q" validate:"required,max=200"`
+	Page  int    `form:"page" validate:"omitempty,gte=1,lte=100"`
+	Type  string `form:"type" validate:"omitempty,oneof=movie series episode"`
+	Year  int    `form:"y" validate:"omitempty,gte=1870,lte=2100"`
+}
+
+// SearchResult is a single provider-neutral movie search hit.
+type SearchResult struct {
+	ImdbID    string `json:"imdb_id"`
+	Title     string `json:"title"`
+	Year      string `json:"year"`
+	Type      string `json:"type"`
+	PosterURL string `json:"poster_url"`
+}
+
+// MovieSearchResponse is a normalized page of search results.
+type MovieSearchResponse struct {
+	Results      []SearchResult `json:"results"`
+	TotalResults int            `json:"total_results"`
+	TotalPages   int            `json:"total_pages"`
+	Page         int            `json:"page"`
+	Next         string         `json:"next,omitempty"`
+	Prev         string         `json:"prev,omitempty"`
+}
+
+// CatalogSearchRequest holds the query parameters accepted by local catalog search.
+type CatalogSearchRequest struct {
+	Query    string `form:"q" validate:"required,max=200"`
+	Page     int    `form:"page" validate:"omitempty,gte=1,lte=1000"`
+	PageSize int    `form:"page_size" validate:"omitempty,gte=1,lte=50"`
+}
+
+// CatalogSearchResponse is a page of movies matched in the local catalog.
+type CatalogSearchResponse struct {
+	Movies       []Movie `json:"movies"`
+	Page         int     `json:"page"`
+	PageSize     int     `json:"page_size"`
+	TotalResults int     `json:"total_results"`
+	TotalPages   int     `json:"total_pages"`
+}
+
+// MovieSort enumerates the orderings supported by the catalog browse endpoint.
+type MovieSort string
+
+const (
+	SortTitle      MovieSort = "title"       // A-Z
+	SortYear       MovieSort = "year"        // newest first
+	SortImdbRating MovieSort = "imdb_rating" // highest first
+	SortPopularity MovieSort = "popularity"  // most watchlisted + rated first
+	SortRecent     MovieSort = "recent"      // most recently added first
+)
+
+// MovieListRequest holds the query parameters accepted by catalog browse.
+type MovieListRequest struct {
+	Genre              string    `form:"genre" validate:"max=100"`
+	YearFrom           int       `form:"year_from" validate:"omitempty,gte=1870,lte=2100"`
+	YearTo             int       `form:"year_to" validate:"omitempty,gte=1870,lte=2100"`
+	Director           string    `form:"director" validate:"max=255"`
+	MinImdbRating      float64   `form:"min_imdb_rating" validate:"omitempty,gte=0,lte=10"`
+	MinCommunityRating float64   `form:"min_community_rating" validate:"omitempty,gte=1,lte=10"`
+	Sort               MovieSort `form:"sort" validate:"omitempty,oneof=title year imdb_rating popularity recent"`
+	Limit              int       `form:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor             string    `form:"cursor"`
+}
+
+// MovieCursor is the decoded keyset position of a browse page: the sort key
+// and ID of the last movie returned.
+type MovieCursor struct {
+	Sort  MovieSort `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// MovieListQuery is a validated browse request as passed to the repository.
+type MovieListQuery struct {
+	Filter MovieListRequest
+	After  *MovieCursor
+	Limit  int
+}
+
+// CatalogMovie is a stored movie with its community aggregates.
+type CatalogMovie struct {
+	Movie
+	CommunityRating *float64 `json:"community_rating,omitempty"`
+	RatingCount     int      `json:"rating_count"`
+	WatchlistCount  int      `json:"watchlist_count"`
+}
+
+// SortValue returns the movie's key for the given ordering, as stored in a
+// MovieCursor. Missing years and ratings sort as 0 and -1 respectively.
+func (m *CatalogMovie) SortValue(sort MovieSort) string {
+	switch sort {
+	case SortTitle:
+		return m.Title
+	case SortYear:
+		if m.ReleaseYear == nil {
+			return "0"
+		}
+		return strconv.Itoa(*m.ReleaseYear)
+	case SortImdbRating:
+		if m.ImdbScore == nil {
+			return "-1"
+		}
+		return strconv.FormatFloat(*m.ImdbScore, 'f', -1, 64)
+	case SortPopularity:
+		return strconv.Itoa(m.RatingCount + m.WatchlistCount)
+	default:
+		return m.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// MovieListResponse is one page of catalog browse results.
+type MovieListResponse struct {
+	Movies     []CatalogMovie `json:"movies"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Next       string         `json:"next,omitempty"`
+}
+
+// CreditRole enumerates the roles a person can have on a movie.
+type CreditRole string
+
+const (
+	CreditDirector CreditRole = "director"
+	CreditWriter   CreditRole = "writer"
+	CreditActor    CreditRole = "actor"
+)
+
+// Credit links a person to a movie in a given role. BillingOrder is the
+// person's position in the provider's listing (0 = first billed).
+type Credit struct {
+	Name         string     `json:"name"`
+	Role         CreditRole `json:"role"`
+	BillingOrder int        `json:"billing_order"`
+}
+
+// BatchMovieRequest is the input for looking up several movies at once.
+type BatchMovieRequest struct {
+	ImdbIDs []string `json:"imdb_ids" validate:"required,min=1,max=100"`
+}
+
+// BatchMovieResult is the outcome of one ID in a batch lookup; exactly one of
+// Movie and Error is set.
+type BatchMovieResult struct {
+	ImdbID string `json:"imdb_id"`
+	Movie  *Movie `json:"movie,omitempty"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchMovieResponse lists batch results in request order.
+type BatchMovieResponse struct {
+	Results []BatchMovieResult `json:"results"`
+	Found   int                `json:"found"`
+	Failed  int                `json:"failed"`
+}
+
+// MovieImport is a movie with its genres and credits, as loaded by the bulk
+// catalog importer.
+type MovieImport struct {
+	Movie   Movie
+	Genres  []string
+	Credits []Credit
 }
 
 // OMDbSearchResult represents a single item from OMDb search.
@@ -57,6 +256,19 @@ type OMDbMovieDetail struct {
 	ImdbRating string `json:"imdbRating"`
 	ImdbID     string `json:"imdbID"`
 	Type       string `json:"Type"`
-	Response   string `json:"Response"`
-	Error      string `json:"Error"`
+	// TotalSeasons is only set for series.
+	TotalSeasons string `json:"totalSeasons"`
+	Response     string `json:"Response"`
+	Error        string `json:"Error"`
+}
+
+// APIKeyQuota reports today's usage of one provider API key. Key is a
+// fingerprint, never the key itself.
+type APIKeyQuota struct {
+	Provider  string `json:"provider"`
+	Key       string `json:"key"`
+	Used      int    `json:"used"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	Exhausted bool   `json:"exhausted"`
 }
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCatalogMovieSortValue(t *testing.T) {
+	year := 1999
+	score := 8.7
+	created := time.Date(2024, 3, 9, 14, 5, 6, 123456789, time.FixedZone("CET", 3600))
+
+	full := CatalogMovie{
+		Movie:          Movie{Title: "The Matrix", ReleaseYear: &year, ImdbScore: &score, CreatedAt: created},
+		RatingCount:    12,
+		WatchlistCount: 30,
+	}
+	bare := CatalogMovie{Movie: Movie{Title: "Untitled", CreatedAt: created}}
+
+	tests := []struct {
+		name  string
+		movie CatalogMovie
+		sort  MovieSort
+		want  string
+	}{
+		{"title", full, SortTitle, "The Matrix"},
+		{"year", full, SortYear, "1999"},
+		{"missing year", bare, SortYear, "0"},
+		{"imdb rating", full, SortImdbRating, "8.7"},
+		{"missing imdb rating", bare, SortImdbRating, "-1"},
+		{"popularity", full, SortPopularity, "42"},
+		{"recent in UTC with nanoseconds", full, SortRecent, "2024-03-09T13:05:06.123456789Z"},
+		{"unknown sort falls back to recent", full, MovieSort(""), "2024-03-09T13:05:06.123456789Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.movie.SortValue(tt.sort); got != tt.want {
+				t.Fatalf("SortValue(%q) = %q, want %q", tt.sort, got, tt.want)
+			}
+		})
+	}
+
+	// The recent key must parse back to the same instant, or the next page
+	// would repeat or skip rows created in the same second.
+	parsed, err := time.Parse(time.RFC3339Nano, full.SortValue(SortRecent))
+	if err != nil || !parsed.Equal(created) {
+		t.Fatalf("recent key parses to %v (%v), want %v", parsed, err, created)
+	}
+}
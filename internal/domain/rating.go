@@ -15,6 +15,7 @@ type Rating struct {
 	Review    string    `json:"review,omitempty" db:"review"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	Tags      []string  `json:"tags"`
 	Movie     *Movie    `json:"movie,omitempty"` // joined data
 }
 
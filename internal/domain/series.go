@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Episode is a single episode of a series. Series themselves are stored as
+// movies with Type "series".
+type Episode struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	SeriesID   uuid.UUID  `json:"series_id" db:"series_id"`
+	Season     int        `json:"season" db:"season"`
+	Episode    int        `json:"episode" db:"episode"`
+	ImdbID     string     `json:"imdb_id" db:"imdb_id"`
+	Title      string     `json:"title" db:"title"`
+	Released   *time.Time `json:"released,omitempty" db:"released"`
+	ImdbRating string     `json:"imdb_rating" db:"imdb_rating"`
+	WatchedAt  *time.Time `json:"watched_at,omitempty"` // set in progress views
+}
+
+// Code returns the conventional "S02E05" label.
+func (e *Episode) Code() string {
+	return EpisodeCode(e.Season, e.Episode)
+}
+
+// EpisodeCode formats a season/episode pair as "S02E05".
+func EpisodeCode(season, episode int) string {
+	return fmt.Sprintf("S%02dE%02d", season, episode)
+}
+
+// Season groups a series' episodes by season number.
+type Season struct {
+	Number   int       `json:"season"`
+	Episodes []Episode `json:"episodes"`
+}
+
+// SeriesProgress reports how far a user is through a series on their watchlist.
+type SeriesProgress struct {
+	WatchlistID uuid.UUID       `json:"watchlist_id"`
+	Status      WatchlistStatus `json:"status"`
+	Watched     int             `json:"watched"`
+	Total       int             `json:"total"`
+	LastWatched string          `json:"last_watched,omitempty"` // e.g. "S02E05"
+	NextEpisode *Episode        `json:"next_episode,omitempty"`
+	Seasons     []Season        `json:"seasons"`
+}
+
+// OMDbEpisode is one entry of an OMDb season response.
+type OMDbEpisode struct {
+	Title      string `json:"Title"`
+	Released   string `json:"Released"`
+	Episode    string `json:"Episode"`
+	ImdbRating string `json:"imdbRating"`
+	ImdbID     string `json:"imdbID"`
+}
+
+// OMDbSeasonResponse is the OMDb response for a `Season=` lookup.
+type OMDbSeasonResponse struct {
+	Title        string        `json:"Title"`
+	Season       string        `json:"Season"`
+	TotalSeasons string        `json:"totalSeasons"`
+	Episodes     []OMDbEpisode `json:"Episodes"`
+	Response     string        `json:"Response"`
+	Error        string        `json:"Error"`
+}
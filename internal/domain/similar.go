@@ -0,0 +1,50 @@
+package domain
+
+// SimilarMoviesRequest holds query params for the similar movies endpoint.
+type SimilarMoviesRequest struct {
+	Limit int `form:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// SimilaritySource summarises the movie that candidates are compared to.
+type SimilaritySource struct {
+	GenreCount    int
+	DirectorCount int
+	ActorCount    int
+	LikeCount     int // users who rated it 7 or higher
+}
+
+// SimilarityCandidate is a movie sharing at least one genre, director, actor
+// or liker with the source movie, with the raw overlap behind each factor.
+type SimilarityCandidate struct {
+	Movie           Movie
+	SharedGenres    []string
+	GenreCount      int
+	SharedDirectors []string
+	SharedActors    []string
+	CoLikes         int // users who rated both movies 7 or higher
+	LikeCount       int
+}
+
+// SimilarityFactor explains one component of a similarity score. Score is
+// the factor's own 0-1 similarity; Contribution is its weighted share of the
+// total.
+type SimilarityFactor struct {
+	Factor       string   `json:"factor"`
+	Score        float64  `json:"score"`
+	Contribution float64  `json:"contribution"`
+	Shared       []string `json:"shared,omitempty"`
+}
+
+// SimilarMovie is a movie ranked by similarity, with the factors that
+// contributed to its score.
+type SimilarMovie struct {
+	Movie   Movie              `json:"movie"`
+	Score   float64            `json:"score"`
+	Factors []SimilarityFactor `json:"factors"`
+}
+
+// SimilarMoviesResponse lists the movies most similar to ImdbID.
+type SimilarMoviesResponse struct {
+	ImdbID  string         `json:"imdb_id"`
+	Results []SimilarMovie `json:"results"`
+}
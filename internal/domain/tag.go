@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is a user's personal label for watchlist entries and ratings. Names
+// are stored trimmed and lower case, so "4K" and "4k" are the same tag.
+type Tag struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	Name           string    `json:"name" db:"name"`
+	WatchlistCount int       `json:"watchlist_count"`
+	RatingCount    int       `json:"rating_count"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// SetTagsRequest replaces the tags on a watchlist entry or rating. Tags the
+// user has not used before are created; an empty list removes all tags.
+type SetTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=20,dive,required,max=50"`
+}
+
+// RenameTagRequest is the input for renaming a tag.
+type RenameTagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// MergeTagRequest names the tag that another tag is merged into.
+type MergeTagRequest struct {
+	IntoTagID uuid.UUID `json:"into_tag_id" validate:"required"`
+}
+
+// RatingListRequest holds the query params for listing a user's ratings.
+// Only ratings carrying every given tag are returned.
+type RatingListRequest struct {
+	Tags []string `form:"tag" validate:"max=10,dive,max=50"`
+}
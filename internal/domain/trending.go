@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// RankingKind selects which activity ranking to read.
+type RankingKind string
+
+const (
+	// RankingTrending favours recent activity: scores decay with a short
+	// half-life relative to the window.
+	RankingTrending RankingKind = "trending"
+	// RankingPopular weighs activity across the whole window more evenly.
+	RankingPopular RankingKind = "popular"
+)
+
+// RankingKinds lists every materialized ranking.
+var RankingKinds = []RankingKind{RankingTrending, RankingPopular}
+
+// RankingWindow is a sliding window of user activity.
+type RankingWindow string
+
+const (
+	Window24h RankingWindow = "24h"
+	Window7d  RankingWindow = "7d"
+	Window30d RankingWindow = "30d"
+)
+
+// RankingWindows maps each window to its length.
+var RankingWindows = map[RankingWindow]time.Duration{
+	Window24h: 24 * time.Hour,
+	Window7d:  7 * 24 * time.Hour,
+	Window30d: 30 * 24 * time.Hour,
+}
+
+// ScoredID is a sorted set member with its score.
+type ScoredID struct {
+	ID    string
+	Score float64
+}
+
+// RankedMoviesRequest holds query params for the trending and popular lists.
+type RankedMoviesRequest struct {
+	Window RankingWindow `form:"window" validate:"omitempty,oneof=24h 7d 30d"`
+	Limit  int           `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// RankedMovie is a movie with its position and activity score.
+type RankedMovie struct {
+	Rank  int     `json:"rank"`
+	Score float64 `json:"score"`
+	Movie Movie   `json:"movie"`
+}
+
+// RankedMoviesResponse is one materialized ranking. UpdatedAt is when the
+// ranking was last computed; it is nil before the first run.
+type RankedMoviesResponse struct {
+	Kind      RankingKind   `json:"kind"`
+	Window    RankingWindow `json:"window"`
+	Movies    []RankedMovie `json:"movies"`
+	UpdatedAt *time.Time    `json:"updated_at"`
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,23 +16,123 @@ const (
 	StatusWatched     WatchlistStatus = "watched"
 )
 
-// Watchlist represents a user's watchlist entry.
+// WatchlistPriority ranks entries from none (0) to high (3).
+type WatchlistPriority int
+
+const (
+	PriorityNone   WatchlistPriority = 0
+	PriorityLow    WatchlistPriority = 1
+	PriorityMedium WatchlistPriority = 2
+	PriorityHigh   WatchlistPriority = 3
+)
+
+// WatchlistSort enumerates the orderings of a list's entries.
+type WatchlistSort string
+
+const (
+	WatchlistSortPosition WatchlistSort = "position" // manual order
+	WatchlistSortAddedAt  WatchlistSort = "added_at" // most recently added first
+	WatchlistSortTitle    WatchlistSort = "title"    // A-Z
+	WatchlistSortYear     WatchlistSort = "year"     // newest first
+	WatchlistSortPriority WatchlistSort = "priority" // highest first, then manual order
+)
+
+// Watchlist represents a user's watchlist entry. Position is the entry's
+// place in the list's manual order, lowest first; positions are fractional
+// so a move only rewrites the moved entry.
 type Watchlist struct {
-	ID      uuid.UUID       `json:"id" db:"id"`
-	UserID  uuid.UUID       `json:"user_id" db:"user_id"`
-	MovieID uuid.UUID       `json:"movie_id" db:"movie_id"`
-	Status  WatchlistStatus `json:"status" db:"status"`
-	AddedAt time.Time       `json:"added_at" db:"added_at"`
-	Movie   *Movie          `json:"movie,omitempty"` // joined data
+	ID       uuid.UUID         `json:"id" db:"id"`
+	UserID   uuid.UUID         `json:"user_id" db:"user_id"`
+	ListID   uuid.UUID         `json:"list_id" db:"list_id"`
+	MovieID  uuid.UUID         `json:"movie_id" db:"movie_id"`
+	Status   WatchlistStatus   `json:"status" db:"status"`
+	Priority WatchlistPriority `json:"priority" db:"priority"`
+	Note     string            `json:"note" db:"note"`
+	Position float64           `json:"position" db:"position"`
+	AddedAt  time.Time         `json:"added_at" db:"added_at"`
+	Tags     []string          `json:"tags"`
+	Movie    *Movie            `json:"movie,omitempty"` // joined data
 }
 
 // AddToWatchlistRequest is the input for adding a movie to the watchlist.
+// New entries go to the top of the list's manual order.
 type AddToWatchlistRequest struct {
-	ImdbID string          `json:"imdb_id" validate:"required"`
-	Status WatchlistStatus `json:"status" validate:"omitempty,oneof=plan_to_watch watching watched"`
+	ImdbID   string            `json:"imdb_id" validate:"required"`
+	Status   WatchlistStatus   `json:"status" validate:"omitempty,oneof=plan_to_watch watching watched"`
+	Priority WatchlistPriority `json:"priority" validate:"min=0,max=3"`
+	Note     string            `json:"note" validate:"max=2000"`
 }
 
-// UpdateWatchlistRequest is the input for updating a watchlist entry.
+// UpdateWatchlistRequest is the input for updating a watchlist entry. Only
+// the fields present are changed; an empty note clears it.
 type UpdateWatchlistRequest struct {
-	Status WatchlistStatus `json:"status" validate:"required,oneof=plan_to_watch watching watched"`
+	Status   WatchlistStatus    `json:"status" validate:"omitempty,oneof=plan_to_watch watching watched"`
+	Priority *WatchlistPriority `json:"priority" validate:"omitempty,min=0,max=3"`
+	Note     *string            `json:"note" validate:"omitempty,max=2000"`
+}
+
+// MoveWatchlistRequest places an entry directly after another entry on the
+// same list, or at the top when AfterID is null.
+type MoveWatchlistRequest struct {
+	AfterID *uuid.UUID `json:"after_id"`
+}
+
+// WatchlistListRequest holds the query params for listing a list's entries.
+// Title matches a case-insensitive substring of the movie title, and only
+// entries carrying every given tag are returned.
+type WatchlistListRequest struct {
+	Status   WatchlistStatus `form:"status" validate:"omitempty,oneof=plan_to_watch watching watched"`
+	Genre    string          `form:"genre" validate:"max=100"`
+	YearFrom int             `form:"year_from" validate:"omitempty,gte=1870,lte=2100"`
+	YearTo   int             `form:"year_to" validate:"omitempty,gte=1870,lte=2100"`
+	Title    string          `form:"title" validate:"max=255"`
+	Tags     []string        `form:"tag" validate:"max=10,dive,max=50"`
+	Sort     WatchlistSort   `form:"sort" validate:"omitempty,oneof=position added_at title year priority"`
+	Limit    int             `form:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor   string          `form:"cursor"`
+}
+
+// WatchlistCursor is the decoded keyset position of a page of entries: the
+// sort key values and ID of the last entry returned.
+type WatchlistCursor struct {
+	Sort   WatchlistSort `json:"s"`
+	Values []string      `json:"v"`
+	ID     uuid.UUID     `json:"id"`
+}
+
+// WatchlistQuery is a validated listing request as passed to the repository.
+type WatchlistQuery struct {
+	ListID uuid.UUID
+	Filter WatchlistListRequest
+	After  *WatchlistCursor
+	Limit  int
+}
+
+// SortValues returns the entry's keys for the given ordering, as stored in a
+// WatchlistCursor. The entry's Movie must be loaded; missing years sort as 0.
+func (w *Watchlist) SortValues(sort WatchlistSort) []string {
+	switch sort {
+	case WatchlistSortAddedAt:
+		return []string{w.AddedAt.UTC().Format(time.RFC3339Nano)}
+	case WatchlistSortTitle:
+		return []string{w.Movie.Title}
+	case WatchlistSortYear:
+		if w.Movie.ReleaseYear == nil {
+			return []string{"0"}
+		}
+		return []string{strconv.Itoa(*w.Movie.ReleaseYear)}
+	case WatchlistSortPriority:
+		return []string{strconv.Itoa(int(w.Priority)), strconv.FormatFloat(-w.Position, 'g', -1, 64)}
+	default:
+		return []string{strconv.FormatFloat(w.Position, 'g', -1, 64)}
+	}
+}
+
+// WatchlistPage is one page of a list's entries. Total counts every entry
+// matching the filters, across all pages.
+type WatchlistPage struct {
+	Entries    []Watchlist `json:"entries"`
+	Total      int         `json:"total"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	Next       string      `json:"next,omitempty"`
 }
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatchlistSortValues(t *testing.T) {
+	year := 2010
+	added := time.Date(2024, 1, 2, 3, 4, 5, 600, time.FixedZone("EST", -5*3600))
+	entry := Watchlist{
+		Priority: 2,
+		Position: 1536.25,
+		AddedAt:  added,
+		Movie:    &Movie{Title: "Inception", ReleaseYear: &year},
+	}
+	undated := entry
+	undated.Movie = &Movie{Title: "Untitled"}
+
+	tests := []struct {
+		name  string
+		entry Watchlist
+		sort  WatchlistSort
+		want  []string
+	}{
+		{"position", entry, WatchlistSortPosition, []string{"1536.25"}},
+		{"added at in UTC", entry, WatchlistSortAddedAt, []string{"2024-01-02T08:04:05.0000006Z"}},
+		{"title", entry, WatchlistSortTitle, []string{"Inception"}},
+		{"year", entry, WatchlistSortYear, []string{"2010"}},
+		{"missing year", undated, WatchlistSortYear, []string{"0"}},
+		// Priority sorts descending, then manual order ascending, so the
+		// position key is negated to share one comparison direction.
+		{"priority", entry, WatchlistSortPriority, []string{"2", "-1536.25"}},
+		{"unknown sort falls back to position", entry, WatchlistSort(""), []string{"1536.25"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.SortValues(tt.sort); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SortValues(%q) = %q, want %q", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type AdminHandler struct {
+	movieService        *service.MovieService
+	autocompleteService *service.AutocompleteService
+}
+
+func NewAdminHandler(movieService *service.MovieService, autocompleteService *service.AutocompleteService) *AdminHandler {
+	return &AdminHandler{
+		movieService:        movieService,
+		autocompleteService: autocompleteService,
+	}
+}
+
+// GetProviders returns the health state of each movie metadata provider.
+func (h *AdminHandler) GetProviders(c *gin.Context) {
+	response.OK(c, "provider health retrieved", h.movieService.ProviderHealth())
+}
+
+// GetProviderQuota returns today's usage and remaining quota for each API key.
+func (h *AdminHandler) GetProviderQuota(c *gin.Context) {
+	quota, err := h.movieService.ProviderQuota(c.Request.Context())
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "provider quota retrieved", quota)
+}
+
+// RebuildAutocomplete re-indexes every stored title in the background, e.g.
+// after a bulk import that bypassed the normal write path.
+func (h *AdminHandler) RebuildAutocomplete(c *gin.Context) {
+	if err := h.autocompleteService.StartRebuild(c.Request.Context()); err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.APIResponse{Success: true, Message: "autocomplete rebuild started"})
+}
@@ -1,15 +1,12 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 
 	"github.com/namru/movie-recommend/internal/domain"
 	appErr "github.com/namru/movie-recommend/internal/errors"
 	"github.com/namru/movie-recommend/internal/service"
 	"github.com/namru/movie-recommend/pkg/response"
-	"github.com/namru/movie-recommend/pkg/validator"
 )
 
 type AuthHandler struct {
@@ -32,18 +29,7 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 // @Router /api/v1/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req domain.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid request body")
-		return
-	}
-
-	if err := validator.Validate.Struct(req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		c.JSON(http.StatusBadRequest, response.APIResponse{
-			Success: false,
-			Error:   "validation failed",
-			Data:    errors,
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -68,18 +54,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req domain.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid request body")
-		return
-	}
-
-	if err := validator.Validate.Struct(req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		c.JSON(http.StatusBadRequest, response.APIResponse{
-			Success: false,
-			Error:   "validation failed",
-			Data:    errors,
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/namru/movie-recommend/pkg/response"
+	"github.com/namru/movie-recommend/pkg/validator"
+)
+
+// bindJSON decodes and validates the request body into req, writing a 400
+// response and returning false if either step fails.
+func bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		response.BadRequest(c, "invalid request body")
+		return false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		errors := validator.FormatValidationErrors(err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Error:   "validation failed",
+			Data:    errors,
+		})
+		return false
+	}
+	return true
+}
+
+// bindQuery decodes and validates query parameters into req, writing a 400
+// response and returning false if either step fails. details, when given,
+// are returned as the response data of a decode failure, to tell clients
+// which parameters must be numeric.
+func bindQuery(c *gin.Context, req interface{}, details ...string) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		if len(details) == 0 {
+			response.BadRequest(c, "invalid query parameters")
+			return false
+		}
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Error:   "invalid query parameters",
+			Data:    details,
+		})
+		return false
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		errors := validator.FormatValidationErrors(err)
+		c.JSON(http.StatusBadRequest, response.APIResponse{
+			Success: false,
+			Error:   "validation failed",
+			Data:    errors,
+		})
+		return false
+	}
+	return true
+}
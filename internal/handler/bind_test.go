@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+func TestBindQueryErrorDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		query     string
+		details   []string
+		wantOK    bool
+		wantError string
+		wantData  interface{}
+	}{
+		{"valid", "?q=matrix&page=2", nil, true, "", nil},
+		{"decode failure with details", "?q=matrix&page=two", []string{"page and page_size must be integers"}, false,
+			"invalid query parameters", []interface{}{"page and page_size must be integers"}},
+		{"decode failure without details", "?q=matrix&page=two", nil, false, "invalid query parameters", nil},
+		{"validation failure lists fields", "?page=2", []string{"unused"}, false, "validation failed", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			var req domain.CatalogSearchRequest
+			if ok := bindQuery(c, &req, tt.details...); ok != tt.wantOK {
+				t.Fatalf("bindQuery = %v, want %v", ok, tt.wantOK)
+			}
+			if tt.wantOK {
+				return
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", w.Code)
+			}
+			var body response.APIResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error != tt.wantError {
+				t.Errorf("error = %q, want %q", body.Error, tt.wantError)
+			}
+			if tt.wantError == "validation failed" {
+				if body.Data == nil {
+					t.Error("validation failure has no field details")
+				}
+				return
+			}
+			if !reflect.DeepEqual(body.Data, tt.wantData) {
+				t.Errorf("data = %v, want %v", body.Data, tt.wantData)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type CurationHandler struct {
+	curationService *service.CurationService
+}
+
+func NewCurationHandler(curationService *service.CurationService) *CurationHandler {
+	return &CurationHandler{curationService: curationService}
+}
+
+// Edit corrects fields of a stored movie; edited fields are locked.
+func (h *CurationHandler) Edit(c *gin.Context) {
+	var req domain.MovieEditRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	movie, err := h.curationService.Edit(c.Request.Context(), getUserID(c), c.Param("imdbID"), req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "movie updated", movie)
+}
+
+// SetLocks replaces the fields protected from upstream refreshes.
+func (h *CurationHandler) SetLocks(c *gin.Context) {
+	var req domain.MovieLocksRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	movie, err := h.curationService.SetLocks(c.Request.Context(), getUserID(c), c.Param("imdbID"), req.Fields)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "locked fields updated", movie)
+}
+
+// SetHidden hides a movie from search and recommendations, or shows it again.
+func (h *CurationHandler) SetHidden(c *gin.Context) {
+	var req domain.MovieHiddenRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	movie, err := h.curationService.SetHidden(c.Request.Context(), getUserID(c), c.Param("imdbID"), *req.Hidden)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "movie visibility updated", movie)
+}
+
+// Merge folds a duplicate movie into another one.
+func (h *CurationHandler) Merge(c *gin.Context) {
+	var req domain.MovieMergeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.curationService.Merge(c.Request.Context(), getUserID(c), c.Param("imdbID"), req.IntoImdbID)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "movies merged", result)
+}
+
+// AuditLog returns the curation history of a movie.
+func (h *CurationHandler) AuditLog(c *gin.Context) {
+	entries, err := h.curationService.AuditLog(c.Request.Context(), c.Param("imdbID"))
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "audit log retrieved", entries)
+}
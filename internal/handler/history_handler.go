@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type HistoryHandler struct {
+	historyService *service.HistoryService
+}
+
+func NewHistoryHandler(historyService *service.HistoryService) *HistoryHandler {
+	return &HistoryHandler{historyService: historyService}
+}
+
+// Timeline returns a page of the user's viewings, newest first.
+func (h *HistoryHandler) Timeline(c *gin.Context) {
+	var req domain.WatchHistoryRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	page, err := h.historyService.Timeline(c.Request.Context(), getUserID(c), req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+	if page.NextCursor != "" {
+		page.Next = queryLink(c, "cursor", page.NextCursor)
+	}
+
+	response.OK(c, "watch history retrieved", page)
+}
+
+// Log records a viewing.
+func (h *HistoryHandler) Log(c *gin.Context) {
+	var req domain.CreateWatchEventRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	event, err := h.historyService.Log(c.Request.Context(), getUserID(c), &req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.Created(c, "viewing logged", event)
+}
+
+// Get returns one viewing.
+func (h *HistoryHandler) Get(c *gin.Context) {
+	eventID, ok := eventIDParam(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.historyService.Get(c.Request.Context(), getUserID(c), eventID)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "viewing retrieved", event)
+}
+
+// Update changes a logged viewing.
+func (h *HistoryHandler) Update(c *gin.Context) {
+	eventID, ok := eventIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateWatchEventRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	event, err := h.historyService.Update(c.Request.Context(), getUserID(c), eventID, &req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "viewing updated", event)
+}
+
+// Delete removes a logged viewing.
+func (h *HistoryHandler) Delete(c *gin.Context) {
+	eventID, ok := eventIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.historyService.Delete(c.Request.Context(), getUserID(c), eventID); err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "viewing deleted", nil)
+}
+
+// eventIDParam parses the :id path parameter, writing a 400 response and
+// returning false if it is not a UUID.
+func eventIDParam(c *gin.Context) (uuid.UUID, bool) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid watch event ID")
+		return uuid.Nil, false
+	}
+	return eventID, true
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type ListHandler struct {
+	listService *service.ListService
+}
+
+func NewListHandler(listService *service.ListService) *ListHandler {
+	return &ListHandler{listService: listService}
+}
+
+// GetAll returns the user's lists in display order.
+func (h *ListHandler) GetAll(c *gin.Context) {
+	lists, err := h.listService.GetAll(c.Request.Context(), getUserID(c))
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "lists retrieved", lists)
+}
+
+// Create adds a named list.
+func (h *ListHandler) Create(c *gin.Context) {
+	var req domain.CreateListRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	list, err := h.listService.Create(c.Request.Context(), getUserID(c), &req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.Created(c, "list created", list)
+}
+
+// Rename changes a list's name.
+func (h *ListHandler) Rename(c *gin.Context) {
+	listID, ok := listIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.RenameListRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	list, err := h.listService.Rename(c.Request.Context(), getUserID(c), listID, &req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "list renamed", list)
+}
+
+// Reorder sets the display order of the user's lists.
+func (h *ListHandler) Reorder(c *gin.Context) {
+	var req domain.ReorderListsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	lists, err := h.listService.Reorder(c.Request.Context(), getUserID(c), req.ListIDs)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "lists reordered", lists)
+}
+
+// Delete removes a list and its entries.
+func (h *ListHandler) Delete(c *gin.Context) {
+	listID, ok := listIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.listService.Delete(c.Request.Context(), getUserID(c), listID); err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "list deleted", nil)
+}
+
+// listIDParam parses the :listID path parameter, writing a 400 response and
+// returning false if it is not a UUID.
+func listIDParam(c *gin.Context) (uuid.UUID, bool) {
+	listID, err := uuid.Parse(c.Param("listID"))
+	if err != nil {
+		response.BadRequest(c, "invalid list ID")
+		return uuid.Nil, false
+	}
+	return listID, true
+}
@@ -1,62 +1,101 @@
 package handler
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 
+	"github.com/namru/movie-recommend/internal/domain"
 	appErr "github.com/namru/movie-recommend/internal/errors"
 	"github.com/namru/movie-recommend/internal/service"
 	"github.com/namru/movie-recommend/pkg/response"
 )
 
 type MovieHandler struct {
-	movieService *service.MovieService
+	movieService        *service.MovieService
+	statsService        *service.StatsService
+	autocompleteService *service.AutocompleteService
 }
 
-func NewMovieHandler(movieService *service.MovieService) *MovieHandler {
-	return &MovieHandler{movieService: movieService}
+func NewMovieHandler(
+	movieService *service.MovieService,
+	statsService *service.StatsService,
+	autocompleteService *service.AutocompleteService,
+) *MovieHandler {
+	return &MovieHandler{
+		movieService:        movieService,
+		statsService:        statsService,
+		autocompleteService: autocompleteService,
+	}
 }
 
 // Search godoc
-// @Summary Search movies via OMDb
+// @Summary Search movies via the movie provider
 // @Tags movies
 // @Produce json
 // @Param q query string true "Search query"
-// @Param page query int false "Page number"
+// @Param page query int false "Page number (1-100)"
+// @Param type query string false "movie, series or episode"
+// @Param y query int false "Release year"
 // @Security BearerAuth
 // @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
 // @Router /api/v1/movies/search [get]
 func (h *MovieHandler) Search(c *gin.Context) {
-	query := c.Query("q")
-	if query == "" {
-		response.BadRequest(c, "query parameter 'q' is required")
+	var req domain.MovieSearchRequest
+	if !bindQuery(c, &req, "page and y must be integers") {
 		return
 	}
-
-	page := 1
-	if p := c.Query("page"); p != "" {
-		// Simple conversion
-		for _, ch := range p {
-			if ch >= '0' && ch <= '9' {
-				page = page*10 + int(ch-'0')
-			}
-		}
-		if page <= 0 {
-			page = 1
-		}
+	if req.Page == 0 {
+		req.Page = 1
 	}
 
-	result, err := h.movieService.Search(c.Request.Context(), query, page)
+	result, err := h.movieService.Search(c.Request.Context(), req)
 	if err != nil {
 		status := appErr.MapToHTTPStatus(err)
 		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
 		return
 	}
 
+	if result.Page < result.TotalPages {
+		result.Next = pageLink(c, result.Page+1)
+	}
+	if result.Page > 1 {
+		result.Prev = pageLink(c, result.Page-1)
+	}
+
 	response.OK(c, "movies found", result)
 }
 
+// SearchCatalog godoc
+// @Summary Search movies already stored in the local catalog
+// @Tags movies
+// @Produce json
+// @Param q query string true "Search query"
+// @Param page query int false "Page number (1-1000)"
+// @Param page_size query int false "Results per page (1-50, default 20)"
+// @Security BearerAuth
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/catalog/search [get]
+func (h *MovieHandler) SearchCatalog(c *gin.Context) {
+	var req domain.CatalogSearchRequest
+	if !bindQuery(c, &req, "page and page_size must be integers") {
+		return
+	}
+
+	result, err := h.movieService.SearchCatalog(c.Request.Context(), req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "catalog search results", result)
+}
+
 // GetByImdbID godoc
-// @Summary Get movie details by IMDb ID
+// @Summary Get movie details by IMDb ID, with community stats and the caller's rating/watchlist status
 // @Tags movies
 // @Produce json
 // @Param imdbID path string true "IMDb ID (e.g. tt1234567)"
@@ -77,5 +116,114 @@ func (h *MovieHandler) GetByImdbID(c *gin.Context) {
 		return
 	}
 
-	response.OK(c, "movie details retrieved", movie)
+	detail, err := h.statsService.Enrich(c.Request.Context(), getUserID(c), movie)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "movie details retrieved", detail)
+}
+
+// List godoc
+// @Summary Browse movies stored in the local catalog
+// @Tags movies
+// @Produce json
+// @Param genre query string false "Genre (exact, case-insensitive)"
+// @Param year_from query int false "Earliest release year"
+// @Param year_to query int false "Latest release year"
+// @Param director query string false "Director name (exact, case-insensitive)"
+// @Param min_imdb_rating query number false "Minimum IMDb rating (0-10)"
+// @Param min_community_rating query number false "Minimum average user rating (1-10)"
+// @Param sort query string false "title, year, imdb_rating, popularity or recent (default)"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Opaque cursor from a previous page's next_cursor"
+// @Security BearerAuth
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/movies [get]
+func (h *MovieHandler) List(c *gin.Context) {
+	var req domain.MovieListRequest
+	if !bindQuery(c, &req, "year_from, year_to and limit must be integers; ratings must be numbers") {
+		return
+	}
+
+	result, err := h.movieService.Browse(c.Request.Context(), req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	if result.NextCursor != "" {
+		result.Next = queryLink(c, "cursor", result.NextCursor)
+	}
+
+	response.OK(c, "movies retrieved", result)
+}
+
+// Autocomplete godoc
+// @Summary Title suggestions for search-as-you-type
+// @Tags movies
+// @Produce json
+// @Param q query string true "Start of a title, or of any word in it"
+// @Security BearerAuth
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/movies/autocomplete [get]
+func (h *MovieHandler) Autocomplete(c *gin.Context) {
+	var req domain.AutocompleteRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	suggestions, err := h.autocompleteService.Suggest(c.Request.Context(), req.Query)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "suggestions retrieved", suggestions)
+}
+
+// GetBatch godoc
+// @Summary Look up several movies by IMDb ID in one request
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param body body domain.BatchMovieRequest true "Up to 100 IMDb IDs"
+// @Security BearerAuth
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/movies/batch [post]
+func (h *MovieHandler) GetBatch(c *gin.Context) {
+	var req domain.BatchMovieRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.movieService.GetBatch(c.Request.Context(), req.ImdbIDs)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "movies retrieved", result)
+}
+
+// pageLink returns the current request path and query with page replaced.
+func pageLink(c *gin.Context, page int) string {
+	return queryLink(c, "page", strconv.Itoa(page))
+}
+
+// queryLink returns the current request path and query with one parameter replaced.
+func queryLink(c *gin.Context, name, value string) string {
+	u := *c.Request.URL
+	q := u.Query()
+	q.Set(name, value)
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
 }
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type PosterHandler struct {
+	posterService *service.PosterService
+	maxAge        int // Cache-Control max-age in seconds
+}
+
+func NewPosterHandler(posterService *service.PosterService, maxAgeSeconds int) *PosterHandler {
+	return &PosterHandler{posterService: posterService, maxAge: maxAgeSeconds}
+}
+
+// Get serves a movie's poster, resized to ?w= pixels wide and encoded as
+// ?format= (jpeg or webp) when given.
+func (h *PosterHandler) Get(c *gin.Context) {
+	width := 0
+	if w := c.Query("w"); w != "" {
+		n, err := strconv.Atoi(w)
+		if err != nil || n < 1 {
+			response.BadRequest(c, "w must be a positive integer")
+			return
+		}
+		width = n
+	}
+	format := c.Query("format")
+	if format != "" && format != service.PosterFormatJPEG && format != service.PosterFormatWebP {
+		response.BadRequest(c, "format must be jpeg or webp")
+		return
+	}
+
+	poster, err := h.posterService.Get(c.Request.Context(), c.Param("imdbID"), width, format)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.Header("ETag", poster.ETag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", h.maxAge))
+	if c.GetHeader("If-None-Match") == poster.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, poster.ContentType, poster.Data)
+}
@@ -1,8 +1,6 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
@@ -10,7 +8,6 @@ import (
 	appErr "github.com/namru/movie-recommend/internal/errors"
 	"github.com/namru/movie-recommend/internal/service"
 	"github.com/namru/movie-recommend/pkg/response"
-	"github.com/namru/movie-recommend/pkg/validator"
 )
 
 type RatingHandler struct {
@@ -26,18 +23,7 @@ func (h *RatingHandler) Create(c *gin.Context) {
 	userID := getUserID(c)
 
 	var req domain.CreateRatingRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid request body")
-		return
-	}
-
-	if err := validator.Validate.Struct(req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		c.JSON(http.StatusBadRequest, response.APIResponse{
-			Success: false,
-			Error:   "validation failed",
-			Data:    errors,
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -51,11 +37,16 @@ func (h *RatingHandler) Create(c *gin.Context) {
 	response.Created(c, "movie rated successfully", rating)
 }
 
-// GetAll returns all ratings for the current user.
+// GetAll returns the current user's ratings, optionally filtered by tag.
 func (h *RatingHandler) GetAll(c *gin.Context) {
 	userID := getUserID(c)
 
-	ratings, err := h.ratingService.GetAll(c.Request.Context(), userID)
+	var req domain.RatingListRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	ratings, err := h.ratingService.GetAll(c.Request.Context(), userID, req)
 	if err != nil {
 		status := appErr.MapToHTTPStatus(err)
 		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
@@ -76,18 +67,7 @@ func (h *RatingHandler) Update(c *gin.Context) {
 	}
 
 	var req domain.UpdateRatingRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid request body")
-		return
-	}
-
-	if err := validator.Validate.Struct(req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		c.JSON(http.StatusBadRequest, response.APIResponse{
-			Success: false,
-			Error:   "validation failed",
-			Data:    errors,
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
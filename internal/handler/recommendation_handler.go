@@ -3,6 +3,7 @@ package handler
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/namru/movie-recommend/internal/domain"
 	appErr "github.com/namru/movie-recommend/internal/errors"
 	"github.com/namru/movie-recommend/internal/service"
 	"github.com/namru/movie-recommend/pkg/response"
@@ -29,3 +30,30 @@ func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
 
 	response.OK(c, "recommendations generated", movies)
 }
+
+// GetSimilar godoc
+// @Summary Movies similar to a given movie, with the factors behind each score
+// @Tags recommendations
+// @Produce json
+// @Param imdbID path string true "IMDb ID"
+// @Param limit query int false "Number of movies (1-50, default 10)"
+// @Security BearerAuth
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Failure 404 {object} response.APIResponse
+// @Router /api/v1/movies/{imdbID}/similar [get]
+func (h *RecommendationHandler) GetSimilar(c *gin.Context) {
+	var req domain.SimilarMoviesRequest
+	if !bindQuery(c, &req, "limit must be an integer") {
+		return
+	}
+
+	result, err := h.recService.GetSimilar(c.Request.Context(), c.Param("imdbID"), req.Limit)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "similar movies retrieved", result)
+}
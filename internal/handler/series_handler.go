@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type SeriesHandler struct {
+	seriesService *service.SeriesService
+}
+
+func NewSeriesHandler(seriesService *service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{seriesService: seriesService}
+}
+
+// GetSeason returns the episodes of one season of a series.
+func (h *SeriesHandler) GetSeason(c *gin.Context) {
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil || season < 1 {
+		response.BadRequest(c, "season must be a positive integer")
+		return
+	}
+
+	result, err := h.seriesService.GetSeason(c.Request.Context(), c.Param("imdbID"), season)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "season retrieved", result)
+}
+
+// GetProgress returns episode progress for a series on the user's watchlist.
+func (h *SeriesHandler) GetProgress(c *gin.Context) {
+	userID := getUserID(c)
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid watchlist entry ID")
+		return
+	}
+
+	progress, err := h.seriesService.GetProgress(c.Request.Context(), userID, entryID)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "series progress retrieved", progress)
+}
+
+// MarkWatched marks an episode (e.g. S02E05) as watched.
+func (h *SeriesHandler) MarkWatched(c *gin.Context) {
+	h.setWatched(c, true)
+}
+
+// UnmarkWatched clears the watched mark on an episode.
+func (h *SeriesHandler) UnmarkWatched(c *gin.Context) {
+	h.setWatched(c, false)
+}
+
+func (h *SeriesHandler) setWatched(c *gin.Context, watched bool) {
+	userID := getUserID(c)
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid watchlist entry ID")
+		return
+	}
+
+	season, episode, ok := parseEpisodeCode(c.Param("code"))
+	if !ok {
+		response.BadRequest(c, "episode must look like S02E05")
+		return
+	}
+
+	progress, err := h.seriesService.SetEpisodeWatched(c.Request.Context(), userID, entryID, season, episode, watched)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "episode progress updated", progress)
+}
+
+var episodeCodePattern = regexp.MustCompile(`^[sS](\d{1,3})[eE](\d{1,4})$`)
+
+// parseEpisodeCode parses "S02E05" (case-insensitive) into season 2, episode 5.
+func parseEpisodeCode(code string) (season, episode int, ok bool) {
+	m := episodeCodePattern.FindStringSubmatch(code)
+	if m == nil {
+		return 0, 0, false
+	}
+	season, _ = strconv.Atoi(m[1])
+	episode, _ = strconv.Atoi(m[2])
+	return season, episode, season > 0 && episode > 0
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type TagHandler struct {
+	tagService *service.TagService
+}
+
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// GetAll returns the user's tags with usage counts.
+func (h *TagHandler) GetAll(c *gin.Context) {
+	tags, err := h.tagService.GetAll(c.Request.Context(), getUserID(c))
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "tags retrieved", tags)
+}
+
+// Rename changes a tag's name.
+func (h *TagHandler) Rename(c *gin.Context) {
+	tagID, ok := tagIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.RenameTagRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	tag, err := h.tagService.Rename(c.Request.Context(), getUserID(c), tagID, req.Name)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "tag renamed", tag)
+}
+
+// Merge folds a tag into another one.
+func (h *TagHandler) Merge(c *gin.Context) {
+	tagID, ok := tagIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.MergeTagRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	tag, err := h.tagService.Merge(c.Request.Context(), getUserID(c), tagID, req.IntoTagID)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "tags merged", tag)
+}
+
+// Delete removes a tag from everything carrying it.
+func (h *TagHandler) Delete(c *gin.Context) {
+	tagID, ok := tagIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tagService.Delete(c.Request.Context(), getUserID(c), tagID); err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "tag deleted", nil)
+}
+
+// SetWatchlistTags replaces the tags on a watchlist entry.
+func (h *TagHandler) SetWatchlistTags(c *gin.Context) {
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid watchlist entry ID")
+		return
+	}
+
+	var req domain.SetTagsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	entry, err := h.tagService.SetWatchlistTags(c.Request.Context(), getUserID(c), entryID, req.Tags)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "watchlist entry tags updated", entry)
+}
+
+// SetRatingTags replaces the tags on a rating.
+func (h *TagHandler) SetRatingTags(c *gin.Context) {
+	ratingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid rating ID")
+		return
+	}
+
+	var req domain.SetTagsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	rating, err := h.tagService.SetRatingTags(c.Request.Context(), getUserID(c), ratingID, req.Tags)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, "rating tags updated", rating)
+}
+
+// tagIDParam parses the :tagID path parameter, writing a 400 response and
+// returning false if it is not a UUID.
+func tagIDParam(c *gin.Context) (uuid.UUID, bool) {
+	tagID, err := uuid.Parse(c.Param("tagID"))
+	if err != nil {
+		response.BadRequest(c, "invalid tag ID")
+		return uuid.Nil, false
+	}
+	return tagID, true
+}
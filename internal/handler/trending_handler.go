@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/service"
+	"github.com/namru/movie-recommend/pkg/response"
+)
+
+type TrendingHandler struct {
+	trendingService *service.TrendingService
+}
+
+func NewTrendingHandler(trendingService *service.TrendingService) *TrendingHandler {
+	return &TrendingHandler{trendingService: trendingService}
+}
+
+// Trending godoc
+// @Summary Movies with the most recent user activity
+// @Tags movies
+// @Produce json
+// @Param window query string false "24h, 7d (default) or 30d"
+// @Param limit query int false "Number of movies (1-100, default 20)"
+// @Security BearerAuth
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/movies/trending [get]
+func (h *TrendingHandler) Trending(c *gin.Context) {
+	h.list(c, domain.RankingTrending)
+}
+
+// Popular godoc
+// @Summary Movies with the most user activity over the window
+// @Tags movies
+// @Produce json
+// @Param window query string false "24h, 7d (default) or 30d"
+// @Param limit query int false "Number of movies (1-100, default 20)"
+// @Security BearerAuth
+// @Success 200 {object} response.APIResponse
+// @Failure 400 {object} response.APIResponse
+// @Router /api/v1/movies/popular [get]
+func (h *TrendingHandler) Popular(c *gin.Context) {
+	h.list(c, domain.RankingPopular)
+}
+
+func (h *TrendingHandler) list(c *gin.Context, kind domain.RankingKind) {
+	var req domain.RankedMoviesRequest
+	if !bindQuery(c, &req, "limit must be an integer") {
+		return
+	}
+
+	result, err := h.trendingService.List(c.Request.Context(), kind, req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.OK(c, string(kind)+" movies retrieved", result)
+}
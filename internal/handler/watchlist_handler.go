@@ -1,8 +1,6 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
@@ -10,7 +8,6 @@ import (
 	appErr "github.com/namru/movie-recommend/internal/errors"
 	"github.com/namru/movie-recommend/internal/service"
 	"github.com/namru/movie-recommend/pkg/response"
-	"github.com/namru/movie-recommend/pkg/validator"
 )
 
 type WatchlistHandler struct {
@@ -21,37 +18,81 @@ func NewWatchlistHandler(watchlistService *service.WatchlistService) *WatchlistH
 	return &WatchlistHandler{watchlistService: watchlistService}
 }
 
-// GetAll returns the user's watchlist.
+// GetList returns a page of the entries on one of the user's lists.
+func (h *WatchlistHandler) GetList(c *gin.Context) {
+	listID, ok := listIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.WatchlistListRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	page, err := h.watchlistService.GetList(c.Request.Context(), getUserID(c), listID, req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+	if page.NextCursor != "" {
+		page.Next = queryLink(c, "cursor", page.NextCursor)
+	}
+
+	response.OK(c, "list entries retrieved", page)
+}
+
+// AddToList adds a movie to one of the user's lists.
+func (h *WatchlistHandler) AddToList(c *gin.Context) {
+	listID, ok := listIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.AddToWatchlistRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	entry, err := h.watchlistService.AddToList(c.Request.Context(), getUserID(c), listID, &req)
+	if err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
+		return
+	}
+
+	response.Created(c, "movie added to list", entry)
+}
+
+// GetAll returns a page of the user's default list.
 func (h *WatchlistHandler) GetAll(c *gin.Context) {
 	userID := getUserID(c)
 
-	entries, err := h.watchlistService.GetAll(c.Request.Context(), userID)
+	var req domain.WatchlistListRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	page, err := h.watchlistService.GetAll(c.Request.Context(), userID, req)
 	if err != nil {
 		status := appErr.MapToHTTPStatus(err)
 		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
 		return
 	}
+	if page.NextCursor != "" {
+		page.Next = queryLink(c, "cursor", page.NextCursor)
+	}
 
-	response.OK(c, "watchlist retrieved", entries)
+	response.OK(c, "watchlist retrieved", page)
 }
 
-// Add adds a movie to the watchlist.
+// Add adds a movie to the user's default list.
 func (h *WatchlistHandler) Add(c *gin.Context) {
 	userID := getUserID(c)
 
 	var req domain.AddToWatchlistRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid request body")
-		return
-	}
-
-	if err := validator.Validate.Struct(req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		c.JSON(http.StatusBadRequest, response.APIResponse{
-			Success: false,
-			Error:   "validation failed",
-			Data:    errors,
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -65,7 +106,7 @@ func (h *WatchlistHandler) Add(c *gin.Context) {
 	response.Created(c, "movie added to watchlist", entry)
 }
 
-// UpdateStatus updates a watchlist entry's status.
+// UpdateStatus updates a watchlist entry's status, priority or note.
 func (h *WatchlistHandler) UpdateStatus(c *gin.Context) {
 	userID := getUserID(c)
 
@@ -76,28 +117,40 @@ func (h *WatchlistHandler) UpdateStatus(c *gin.Context) {
 	}
 
 	var req domain.UpdateWatchlistRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "invalid request body")
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	if err := validator.Validate.Struct(req); err != nil {
-		errors := validator.FormatValidationErrors(err)
-		c.JSON(http.StatusBadRequest, response.APIResponse{
-			Success: false,
-			Error:   "validation failed",
-			Data:    errors,
-		})
+	if err := h.watchlistService.UpdateStatus(c.Request.Context(), userID, entryID, &req); err != nil {
+		status := appErr.MapToHTTPStatus(err)
+		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
 		return
 	}
 
-	if err := h.watchlistService.UpdateStatus(c.Request.Context(), userID, entryID, &req); err != nil {
+	response.OK(c, "watchlist entry updated", nil)
+}
+
+// Move places a watchlist entry after another entry on the same list.
+func (h *WatchlistHandler) Move(c *gin.Context) {
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid watchlist entry ID")
+		return
+	}
+
+	var req domain.MoveWatchlistRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	entry, err := h.watchlistService.Move(c.Request.Context(), getUserID(c), entryID, req.AfterID)
+	if err != nil {
 		status := appErr.MapToHTTPStatus(err)
 		c.JSON(status, response.APIResponse{Success: false, Error: err.Error()})
 		return
 	}
 
-	response.OK(c, "watchlist entry updated", nil)
+	response.OK(c, "watchlist entry moved", entry)
 }
 
 // Remove deletes a watchlist entry.
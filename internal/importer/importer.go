@@ -0,0 +1,400 @@
+// Package importer loads the IMDb non-commercial datasets
+// (https://developer.imdb.com/non-commercial-datasets/) into the movie catalog.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// Dataset file names inside Options.Dir.
+const (
+	titleBasicsFile     = "title.basics.tsv.gz"
+	titleRatingsFile    = "title.ratings.tsv.gz"
+	titlePrincipalsFile = "title.principals.tsv.gz"
+	nameBasicsFile      = "name.basics.tsv.gz"
+)
+
+// maxActors matches the number of actors OMDb lists for a title.
+const maxActors = 4
+
+// imdbTypes maps IMDb titleType values onto the catalog's movie types.
+var imdbTypes = map[string]string{
+	"movie":        "movie",
+	"tvMovie":      "movie",
+	"tvSeries":     "series",
+	"tvMiniSeries": "series",
+}
+
+// Options controls an import run.
+type Options struct {
+	Dir            string   // directory holding the dataset files
+	Types          []string // IMDb titleType values to import
+	MinVotes       int      // skip titles with fewer IMDb votes
+	BatchSize      int      // movies per COPY batch
+	DryRun         bool     // parse and report without writing
+	CheckpointFile string   // last committed tconst, for resuming
+}
+
+// Stats summarizes an import run.
+type Stats struct {
+	Candidates int // titles that passed the filters
+	Skipped    int // titles at or before the checkpoint
+	Inserted   int // movies written (0 in dry-run mode)
+	Batches    int
+}
+
+// Importer loads IMDb titles into the catalog through a MovieRepository.
+type Importer struct {
+	movieRepo repository.MovieRepository
+	opts      Options
+	logger    *zap.Logger
+}
+
+// New builds an Importer. movieRepo may be nil in dry-run mode.
+func New(movieRepo repository.MovieRepository, opts Options, logger *zap.Logger) *Importer {
+	return &Importer{movieRepo: movieRepo, opts: opts, logger: logger}
+}
+
+type rating struct {
+	score string
+	votes int
+}
+
+type title struct {
+	tconst    string
+	name      string
+	kind      string
+	startYear string
+	endYear   string
+	runtime   string
+	genres    []string
+}
+
+type principal struct {
+	nconst   string
+	role     domain.CreditRole
+	ordering int
+}
+
+// Run loads the datasets and writes the selected titles in batches. Titles
+// are processed in file order (ascending tconst), and the last tconst of each
+// committed batch is written to the checkpoint file so an interrupted run
+// resumes where it stopped.
+func (im *Importer) Run(ctx context.Context) (Stats, error) {
+	var stats Stats
+
+	resumeAfter, err := im.readCheckpoint()
+	if err != nil {
+		return stats, err
+	}
+	if resumeAfter > 0 {
+		im.logger.Info("resuming import", zap.String("after", fmt.Sprintf("tt%07d", resumeAfter)))
+	}
+
+	ratings, err := im.loadRatings()
+	if err != nil {
+		return stats, err
+	}
+
+	titles, skipped, err := im.loadTitles(ratings, resumeAfter)
+	if err != nil {
+		return stats, err
+	}
+	stats.Candidates, stats.Skipped = len(titles), skipped
+	if len(titles) == 0 {
+		return stats, nil
+	}
+
+	principals, err := im.loadPrincipals(titles)
+	if err != nil {
+		return stats, err
+	}
+	names, err := im.loadNames(principals)
+	if err != nil {
+		return stats, err
+	}
+
+	now := time.Now()
+	batch := make([]domain.MovieImport, 0, im.opts.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		last := batch[len(batch)-1].Movie.ImdbID
+		stats.Batches++
+		if !im.opts.DryRun {
+			n, err := im.movieRepo.ImportBatch(ctx, batch)
+			if err != nil {
+				return fmt.Errorf("import batch ending at %s: %w", last, err)
+			}
+			stats.Inserted += n
+			if err := im.writeCheckpoint(last); err != nil {
+				return err
+			}
+		}
+		im.logger.Info("batch imported",
+			zap.Int("batch", stats.Batches),
+			zap.Int("size", len(batch)),
+			zap.String("last", last),
+			zap.Bool("dry_run", im.opts.DryRun),
+		)
+		batch = batch[:0]
+		return nil
+	}
+
+	for _, t := range titles {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		batch = append(batch, buildImport(t, ratings[t.tconst], principals[t.tconst], names, now))
+		if len(batch) == im.opts.BatchSize {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+		}
+	}
+	return stats, flush()
+}
+
+func (im *Importer) path(name string) string {
+	return filepath.Join(im.opts.Dir, name)
+}
+
+func (im *Importer) loadRatings() (map[string]rating, error) {
+	ratings := make(map[string]rating)
+	err := scanTSV(im.path(titleRatingsFile), func(f []string) error {
+		votes, _ := strconv.Atoi(field(f, 2))
+		ratings[f[0]] = rating{score: field(f, 1), votes: votes}
+		return nil
+	})
+	im.logger.Info("loaded ratings", zap.Int("count", len(ratings)))
+	return ratings, err
+}
+
+// loadTitles returns the titles to import, in file order, and the number of
+// matching titles skipped because they precede the checkpoint.
+func (im *Importer) loadTitles(ratings map[string]rating, resumeAfter int) ([]title, int, error) {
+	wanted := make(map[string]bool, len(im.opts.Types))
+	for _, t := range im.opts.Types {
+		wanted[t] = true
+	}
+
+	var titles []title
+	skipped := 0
+	err := scanTSV(im.path(titleBasicsFile), func(f []string) error {
+		if !wanted[field(f, 1)] || field(f, 4) == "1" {
+			return nil
+		}
+		if im.opts.MinVotes > 0 && ratings[f[0]].votes < im.opts.MinVotes {
+			return nil
+		}
+		if tconstNumber(f[0]) <= resumeAfter {
+			skipped++
+			return nil
+		}
+		t := title{
+			tconst:    f[0],
+			name:      field(f, 2),
+			kind:      imdbTypes[field(f, 1)],
+			startYear: field(f, 5),
+			endYear:   field(f, 6),
+			runtime:   field(f, 7),
+		}
+		if g := field(f, 8); g != "" {
+			t.genres = strings.Split(g, ",")
+		}
+		if t.name != "" {
+			titles = append(titles, t)
+		}
+		return nil
+	})
+	im.logger.Info("selected titles", zap.Int("count", len(titles)), zap.Int("skipped", skipped))
+	return titles, skipped, err
+}
+
+func (im *Importer) loadPrincipals(titles []title) (map[string][]principal, error) {
+	wanted := make(map[string]bool, len(titles))
+	for _, t := range titles {
+		wanted[t.tconst] = true
+	}
+
+	principals := make(map[string][]principal)
+	err := scanTSV(im.path(titlePrincipalsFile), func(f []string) error {
+		if !wanted[f[0]] {
+			return nil
+		}
+		var role domain.CreditRole
+		switch field(f, 3) {
+		case "director":
+			role = domain.CreditDirector
+		case "writer":
+			role = domain.CreditWriter
+		case "actor", "actress":
+			role = domain.CreditActor
+		default:
+			return nil
+		}
+		ordering, _ := strconv.Atoi(field(f, 1))
+		principals[f[0]] = append(principals[f[0]], principal{nconst: field(f, 2), role: role, ordering: ordering})
+		return nil
+	})
+	im.logger.Info("loaded principals", zap.Int("titles", len(principals)))
+	return principals, err
+}
+
+func (im *Importer) loadNames(principals map[string][]principal) (map[string]string, error) {
+	names := make(map[string]string)
+	for _, ps := range principals {
+		for _, p := range ps {
+			names[p.nconst] = ""
+		}
+	}
+
+	err := scanTSV(im.path(nameBasicsFile), func(f []string) error {
+		if _, ok := names[f[0]]; ok {
+			names[f[0]] = field(f, 1)
+		}
+		return nil
+	})
+	im.logger.Info("resolved names", zap.Int("count", len(names)))
+	return names, err
+}
+
+// buildImport converts an IMDb title into a catalog movie. Imported movies
+// have no plot or poster, so refreshed_at is set to the zero time to put them
+// at the front of the metadata refresh worker's queue.
+func buildImport(t title, r rating, principals []principal, names map[string]string, now time.Time) domain.MovieImport {
+	sort.Slice(principals, func(i, j int) bool { return principals[i].ordering < principals[j].ordering })
+
+	var credits []domain.Credit
+	byRole := make(map[domain.CreditRole][]string)
+	for _, p := range principals {
+		name := names[p.nconst]
+		if name == "" {
+			continue
+		}
+		credits = append(credits, domain.Credit{Name: name, Role: p.role, BillingOrder: len(byRole[p.role])})
+		byRole[p.role] = append(byRole[p.role], name)
+	}
+
+	actors := byRole[domain.CreditActor]
+	if len(actors) > maxActors {
+		actors = actors[:maxActors]
+	}
+
+	year := t.startYear
+	if t.kind == "series" && year != "" {
+		// OMDb style: "2008–2013", or "2019–" while still running.
+		year += "–" + t.endYear
+	}
+
+	m := domain.Movie{
+		ID:          uuid.New(),
+		ImdbID:      t.tconst,
+		Title:       truncate(t.name, 255),
+		Year:        year,
+		Genre:       joinWithin(t.genres, 255),
+		Director:    joinWithin(byRole[domain.CreditDirector], 255),
+		Actors:      strings.Join(actors, ", "),
+		Writer:      strings.Join(byRole[domain.CreditWriter], ", "),
+		ImdbRating:  r.score,
+		Type:        t.kind,
+		ReleaseYear: parseInt(t.startYear),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		RefreshedAt: time.Unix(0, 0).UTC(),
+	}
+	if m.ImdbRating == "" {
+		m.ImdbRating = "N/A"
+	}
+	if score, err := strconv.ParseFloat(r.score, 64); err == nil {
+		m.ImdbScore = &score
+	}
+	if runtime := parseInt(t.runtime); runtime != nil && *runtime > 0 {
+		m.RuntimeMinutes = runtime
+	}
+
+	return domain.MovieImport{Movie: m, Genres: t.genres, Credits: credits}
+}
+
+// readCheckpoint returns the numeric part of the last committed tconst, or 0.
+func (im *Importer) readCheckpoint() (int, error) {
+	if im.opts.CheckpointFile == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(im.opts.CheckpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return tconstNumber(strings.TrimSpace(string(data))), nil
+}
+
+// writeCheckpoint atomically records the last committed tconst.
+func (im *Importer) writeCheckpoint(tconst string) error {
+	if im.opts.CheckpointFile == "" {
+		return nil
+	}
+	tmp := im.opts.CheckpointFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(tconst+"\n"), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, im.opts.CheckpointFile)
+}
+
+// tconstNumber parses "tt0111161" into 111161. IMDb IDs grow in width over
+// time, so they must be compared numerically rather than as strings.
+func tconstNumber(tconst string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(tconst, "tt"))
+	return n
+}
+
+func parseInt(s string) *int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// joinWithin joins items with ", ", dropping trailing items that would push
+// the result past max bytes.
+func joinWithin(items []string, max int) string {
+	out := ""
+	for _, item := range items {
+		next := item
+		if out != "" {
+			next = out + ", " + item
+		}
+		if len(next) > max {
+			break
+		}
+		out = next
+	}
+	return out
+}
+
+// truncate cuts s to at most max runes.
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max])
+}
@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// nullValue is how the IMDb datasets encode a missing field.
+const nullValue = `\N`
+
+// scanTSV streams a gzipped IMDb dataset file, calling fn with the fields of
+// each data row. IMDb files are not CSV-quoted, so rows are split on tabs only.
+func scanTSV(path string, fn func(fields []string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	defer gz.Close()
+
+	r := bufio.NewReaderSize(gz, 1<<20)
+	header := true
+	for {
+		line, err := r.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			if header {
+				header = false
+			} else if ferr := fn(strings.Split(line, "\t")); ferr != nil {
+				return ferr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+}
+
+// field returns fields[i], or "" when it is missing or null.
+func field(fields []string, i int) string {
+	if i >= len(fields) || fields[i] == nullValue {
+		return ""
+	}
+	return fields[i]
+}
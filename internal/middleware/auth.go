@@ -58,6 +58,22 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		}
 
 		c.Set("user_id", userID)
+		if role, ok := claims["role"].(string); ok {
+			c.Set("role", role)
+		}
+		c.Next()
+	}
+}
+
+// AdminMiddleware rejects requests whose token does not carry the admin role.
+// It must run after AuthMiddleware.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") != "admin" {
+			response.Forbidden(c, "admin access required")
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
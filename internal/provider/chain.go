@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/pkg/httpclient"
+)
+
+// Health is a snapshot of a provider's state inside a Chain.
+type Health struct {
+	Name           string     `json:"name"`
+	Healthy        bool       `json:"healthy"`
+	Successes      int64      `json:"successes"`
+	Failures       int64      `json:"failures"`
+	Circuit        string     `json:"circuit,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastFailureAt  *time.Time `json:"last_failure_at,omitempty"`
+	UnhealthyUntil *time.Time `json:"unhealthy_until,omitempty"`
+}
+
+// circuitReporter is implemented by providers backed by an httpclient.Client.
+type circuitReporter interface {
+	BreakerState() httpclient.State
+}
+
+// HealthReporter is implemented by providers that track per-backend health.
+type HealthReporter interface {
+	Health() []Health
+}
+
+// SeasonProvider is implemented by providers that can list a series' episodes.
+type SeasonProvider interface {
+	GetSeason(ctx context.Context, imdbID string, season int) (*domain.OMDbSeasonResponse, error)
+}
+
+// errUnsupported is returned by Chain calls for providers lacking an optional
+// capability; such providers are skipped without counting as failures.
+var errUnsupported = errors.New("operation not supported by provider")
+
+// QuotaReporter is implemented by providers that meter API key usage.
+type QuotaReporter interface {
+	Quota(ctx context.Context) ([]domain.APIKeyQuota, error)
+}
+
+type providerState struct {
+	successes      int64
+	failures       int64
+	lastError      string
+	lastFailureAt  time.Time
+	unhealthyUntil time.Time
+}
+
+// Chain is a MovieProvider that tries an ordered list of providers, falling
+// back to the next one when a provider fails with an upstream error. A failed
+// provider is skipped for the cool-down window unless every provider is cooling
+// down, in which case all of them are tried in order.
+type Chain struct {
+	providers []MovieProvider
+	cooldown  time.Duration
+	logger    *zap.Logger
+
+	mu     sync.Mutex
+	states []providerState
+}
+
+func NewChain(providers []MovieProvider, cooldown time.Duration, logger *zap.Logger) *Chain {
+	return &Chain{
+		providers: providers,
+		cooldown:  cooldown,
+		logger:    logger,
+		states:    make([]providerState, len(providers)),
+	}
+}
+
+// Name returns the provider names joined in fallback order, e.g. "omdb>tmdb".
+func (c *Chain) Name() string {
+	names := make([]string, len(c.providers))
+	for i, p := range c.providers {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ">")
+}
+
+func (c *Chain) Search(ctx context.Context, req domain.MovieSearchRequest) (*domain.MovieSearchResponse, error) {
+	var result *domain.MovieSearchResponse
+	err := c.do(ctx, "search", func(p MovieProvider) error {
+		var err error
+		result, err = p.Search(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+func (c *Chain) GetByID(ctx context.Context, id string) (*domain.OMDbMovieDetail, error) {
+	// Provider-native IDs are only meaningful to the primary provider.
+	return c.providers[0].GetByID(ctx, id)
+}
+
+func (c *Chain) GetByExternalID(ctx context.Context, imdbID string) (*domain.OMDbMovieDetail, error) {
+	var detail *domain.OMDbMovieDetail
+	err := c.do(ctx, "get_by_external_id", func(p MovieProvider) error {
+		var err error
+		detail, err = p.GetByExternalID(ctx, imdbID)
+		return err
+	})
+	return detail, err
+}
+
+// GetSeason fetches one season of a series from the first provider that
+// supports season lookups.
+func (c *Chain) GetSeason(ctx context.Context, imdbID string, season int) (*domain.OMDbSeasonResponse, error) {
+	var resp *domain.OMDbSeasonResponse
+	err := c.do(ctx, "get_season", func(p MovieProvider) error {
+		sp, ok := p.(SeasonProvider)
+		if !ok {
+			return errUnsupported
+		}
+		var err error
+		resp, err = sp.GetSeason(ctx, imdbID, season)
+		return err
+	})
+	return resp, err
+}
+
+// Health returns a snapshot of every provider's counters and cool-down state.
+func (c *Chain) Health() []Health {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	out := make([]Health, len(c.providers))
+	for i, p := range c.providers {
+		st := c.states[i]
+		h := Health{
+			Name:      p.Name(),
+			Healthy:   !now.Before(st.unhealthyUntil),
+			Successes: st.successes,
+			Failures:  st.failures,
+			LastError: st.lastError,
+		}
+		if cr, ok := p.(circuitReporter); ok {
+			h.Circuit = string(cr.BreakerState())
+		}
+		if !st.lastFailureAt.IsZero() {
+			t := st.lastFailureAt
+			h.LastFailureAt = &t
+		}
+		if !h.Healthy {
+			t := st.unhealthyUntil
+			h.UnhealthyUntil = &t
+		}
+		out[i] = h
+	}
+	return out
+}
+
+// Quota collects key usage from every provider that meters its keys.
+func (c *Chain) Quota(ctx context.Context) ([]domain.APIKeyQuota, error) {
+	quotas := []domain.APIKeyQuota{}
+	for _, p := range c.providers {
+		qr, ok := p.(QuotaReporter)
+		if !ok {
+			continue
+		}
+		q, err := qr.Quota(ctx)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, q...)
+	}
+	return quotas, nil
+}
+
+// do runs call against each eligible provider in order until one succeeds or
+// fails with a non-upstream error (e.g. not found).
+func (c *Chain) do(ctx context.Context, op string, call func(MovieProvider) error) error {
+	err := error(appErr.ErrExternalAPI)
+	supported := false
+	for _, i := range c.candidates() {
+		p := c.providers[i]
+
+		res := call(p)
+		if errors.Is(res, errUnsupported) {
+			continue
+		}
+		err, supported = res, true
+		if ctx.Err() != nil {
+			// The caller gave up; that says nothing about the provider.
+			return err
+		}
+		if err == nil || !errors.Is(err, appErr.ErrExternalAPI) {
+			c.recordSuccess(i)
+			return err
+		}
+
+		c.recordFailure(i, err)
+		c.logger.Warn("movie provider failed, trying next",
+			zap.String("provider", p.Name()),
+			zap.String("op", op),
+			zap.Error(err),
+		)
+	}
+	if !supported {
+		return appErr.New(400, "no configured movie provider supports "+op, appErr.ErrBadRequest)
+	}
+	return err
+}
+
+// candidates returns the indexes of providers to try, healthy ones first.
+func (c *Chain) candidates() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var healthy []int
+	for i := range c.providers {
+		if !now.Before(c.states[i].unhealthyUntil) {
+			healthy = append(healthy, i)
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy
+	}
+
+	all := make([]int, len(c.providers))
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
+
+func (c *Chain) recordSuccess(i int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.states[i].successes++
+	c.states[i].unhealthyUntil = time.Time{}
+}
+
+func (c *Chain) recordFailure(i int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	st := &c.states[i]
+	st.failures++
+	st.lastError = err.Error()
+	st.lastFailureAt = now
+	st.unhealthyUntil = now.Add(c.cooldown)
+}
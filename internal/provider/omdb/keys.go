@@ -0,0 +1,190 @@
+package omdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+// UsageStore persists per-key request counters so every instance sees the
+// same daily usage. repository.CacheRepository satisfies it.
+type UsageStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+// keyPool hands out OMDb API keys, sticking with one key until it runs out of
+// quota and then rotating to the next. Keys are only ever logged by their
+// fingerprint.
+type keyPool struct {
+	keys   []string
+	limit  int
+	store  UsageStore
+	logger *zap.Logger
+
+	mu        sync.Mutex
+	current   int
+	exhausted map[int]string // key index -> UTC day it ran out
+}
+
+func newKeyPool(keys []string, limit int, store UsageStore, logger *zap.Logger) *keyPool {
+	return &keyPool{
+		keys:      keys,
+		limit:     limit,
+		store:     store,
+		logger:    logger,
+		exhausted: make(map[int]string),
+	}
+}
+
+// pick returns the index of a key with quota left today. Besides the keys
+// this instance has retired, it skips keys whose shared usage counter shows
+// another instance has spent their daily limit.
+func (p *keyPool) pick(ctx context.Context) (int, bool) {
+	// Every pass either returns or retires a key, so this terminates.
+	for n := 0; n < len(p.keys); n++ {
+		i, ok := p.next()
+		if !ok {
+			return 0, false
+		}
+		if !p.spent(ctx, i) {
+			return i, true
+		}
+		p.markExhausted(i, "daily limit reached")
+	}
+	return 0, false
+}
+
+// next returns the first key, starting from the current one, that this
+// instance has not retired today.
+func (p *keyPool) next() (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	today := day()
+	for n := 0; n < len(p.keys); n++ {
+		i := (p.current + n) % len(p.keys)
+		if p.exhausted[i] == today {
+			continue
+		}
+		p.current = i
+		return i, true
+	}
+	return 0, false
+}
+
+// spent reports whether the shared usage counter for key i has reached the
+// daily limit. Store errors are logged and the key is assumed usable; a 401
+// from OMDb still retires it.
+func (p *keyPool) spent(ctx context.Context, i int) bool {
+	if p.store == nil || p.limit <= 0 {
+		return false
+	}
+	val, err := p.store.Get(ctx, usageKey(p.keys[i]))
+	if err != nil {
+		p.logger.Warn("failed to read omdb key usage", zap.String("key", fingerprint(p.keys[i])), zap.Error(err))
+		return false
+	}
+	used, _ := strconv.Atoi(val)
+	return used >= p.limit
+}
+
+// markExhausted takes a key out of rotation until the next UTC day.
+func (p *keyPool) markExhausted(i int, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	today := day()
+	if p.exhausted[i] == today {
+		return
+	}
+	p.exhausted[i] = today
+	p.logger.Warn("omdb api key taken out of rotation",
+		zap.String("key", fingerprint(p.keys[i])),
+		zap.String("reason", reason),
+	)
+}
+
+// record counts one request against key i, retiring the key once the daily
+// limit is reached.
+func (p *keyPool) record(ctx context.Context, i int) {
+	if p.store == nil {
+		return
+	}
+	used, err := p.store.Incr(ctx, usageKey(p.keys[i]), 48*time.Hour)
+	if err != nil {
+		p.logger.Warn("failed to record omdb key usage", zap.String("key", fingerprint(p.keys[i])), zap.Error(err))
+		return
+	}
+	if p.limit > 0 && used >= int64(p.limit) {
+		p.markExhausted(i, "daily limit reached")
+	}
+}
+
+// quota reports today's usage for every key.
+func (p *keyPool) quota(ctx context.Context) ([]domain.APIKeyQuota, error) {
+	p.mu.Lock()
+	exhausted := make(map[int]bool, len(p.exhausted))
+	today := day()
+	for i, d := range p.exhausted {
+		exhausted[i] = d == today
+	}
+	p.mu.Unlock()
+
+	quotas := make([]domain.APIKeyQuota, 0, len(p.keys))
+	for i, key := range p.keys {
+		q := domain.APIKeyQuota{
+			Provider:  "omdb",
+			Key:       fingerprint(key),
+			Limit:     p.limit,
+			Exhausted: exhausted[i],
+		}
+		if p.store != nil {
+			val, err := p.store.Get(ctx, usageKey(key))
+			if err != nil {
+				return nil, err
+			}
+			q.Used, _ = strconv.Atoi(val)
+		}
+		if p.limit > 0 {
+			q.Remaining = p.limit - q.Used
+			if q.Remaining < 0 || q.Exhausted {
+				q.Remaining = 0
+			}
+		}
+		quotas = append(quotas, q)
+	}
+	return quotas, nil
+}
+
+// redact replaces every configured key in s.
+func (p *keyPool) redact(s string) string {
+	for _, key := range p.keys {
+		s = strings.ReplaceAll(s, key, "[REDACTED]")
+	}
+	return s
+}
+
+// fingerprint identifies a key without revealing it.
+func fingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
+
+func usageKey(key string) string {
+	return fmt.Sprintf("omdb:usage:%s:%s", fingerprint(key), day())
+}
+
+// day returns the current UTC date; OMDb quotas reset daily.
+func day() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
@@ -0,0 +1,120 @@
+package omdb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// memUsage is an in-memory UsageStore; err, when set, fails every call.
+type memUsage struct {
+	counts map[string]int64
+	err    error
+	reads  int
+}
+
+func (m *memUsage) Get(_ context.Context, key string) (string, error) {
+	m.reads++
+	if m.err != nil {
+		return "", m.err
+	}
+	if n, ok := m.counts[key]; ok {
+		return strconv.FormatInt(n, 10), nil
+	}
+	return "", nil
+}
+
+func (m *memUsage) Incr(_ context.Context, key string, _ time.Duration) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.counts[key]++
+	return m.counts[key], nil
+}
+
+func TestKeyPoolPick(t *testing.T) {
+	keys := []string{"key-a", "key-b", "key-c"}
+	const limit = 100
+
+	tests := []struct {
+		name      string
+		used      map[int]int64 // key index -> shared usage today
+		retired   []int         // retired by this instance
+		storeErr  error
+		nilStore  bool
+		limit     int
+		want      int
+		wantOK    bool
+		wantReads int
+	}{
+		{name: "first key", want: 0, wantOK: true, limit: limit, wantReads: 1},
+		{name: "skips key spent by another instance", used: map[int]int64{0: limit}, limit: limit, want: 1, wantOK: true, wantReads: 2},
+		{name: "key just under the limit is usable", used: map[int]int64{0: limit - 1}, limit: limit, want: 0, wantOK: true, wantReads: 1},
+		{name: "skips locally retired key without a read", retired: []int{0}, limit: limit, want: 1, wantOK: true, wantReads: 1},
+		{name: "all keys spent", used: map[int]int64{0: limit, 1: limit + 5, 2: limit}, limit: limit, wantOK: false, wantReads: 3},
+		{name: "all keys retired", retired: []int{0, 1, 2}, limit: limit, wantOK: false, wantReads: 0},
+		{name: "store error assumes the key is usable", storeErr: errors.New("redis down"), limit: limit, want: 0, wantOK: true, wantReads: 1},
+		{name: "no store", nilStore: true, limit: limit, want: 0, wantOK: true},
+		{name: "no limit never reads usage", used: map[int]int64{0: 1 << 20}, limit: 0, want: 0, wantOK: true, wantReads: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memUsage{counts: make(map[string]int64), err: tt.storeErr}
+			for i, n := range tt.used {
+				store.counts[usageKey(keys[i])] = n
+			}
+			var us UsageStore = store
+			if tt.nilStore {
+				us = nil
+			}
+			p := newKeyPool(keys, tt.limit, us, zap.NewNop())
+			for _, i := range tt.retired {
+				p.markExhausted(i, "test")
+			}
+
+			got, ok := p.pick(context.Background())
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Fatalf("pick = %d, %v; want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if store.reads != tt.wantReads {
+				t.Errorf("usage reads = %d, want %d", store.reads, tt.wantReads)
+			}
+		})
+	}
+}
+
+func TestKeyPoolSticksToPickedKey(t *testing.T) {
+	keys := []string{"key-a", "key-b"}
+	store := &memUsage{counts: map[string]int64{usageKey("key-a"): 10}}
+	p := newKeyPool(keys, 10, store, zap.NewNop())
+
+	for n := 0; n < 3; n++ {
+		if i, ok := p.pick(context.Background()); !ok || i != 1 {
+			t.Fatalf("pick %d = %d, %v; want key 1", n+1, i, ok)
+		}
+	}
+	// The spent key was retired locally, so later picks no longer read it.
+	if store.reads != 4 {
+		t.Fatalf("usage reads = %d, want 4", store.reads)
+	}
+}
+
+func TestKeyPoolRecordRetiresAtLimit(t *testing.T) {
+	keys := []string{"key-a", "key-b"}
+	store := &memUsage{counts: make(map[string]int64)}
+	p := newKeyPool(keys, 2, store, zap.NewNop())
+	ctx := context.Background()
+
+	p.record(ctx, 0)
+	if i, _ := p.pick(ctx); i != 0 {
+		t.Fatalf("pick after 1 of 2 requests = %d, want 0", i)
+	}
+	p.record(ctx, 0)
+	if i, ok := p.pick(ctx); !ok || i != 1 {
+		t.Fatalf("pick after the limit = %d, %v; want 1", i, ok)
+	}
+}
@@ -0,0 +1,230 @@
+package omdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/config"
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/pkg/httpclient"
+)
+
+// OMDb answers with these errors when a key can no longer be used today.
+const (
+	errLimitReached  = "Request limit reached!"
+	errInvalidAPIKey = "Invalid API key!"
+)
+
+// Client implements provider.MovieProvider against the OMDb API.
+type Client struct {
+	cfg    *config.OMDBConfig
+	logger *zap.Logger
+	client *httpclient.Client
+	keys   *keyPool
+}
+
+// New builds an OMDb client rotating through cfg.APIKeys. store may be nil,
+// in which case usage is not counted and keys rotate only when OMDb rejects them.
+func New(cfg *config.OMDBConfig, client *httpclient.Client, store UsageStore, logger *zap.Logger) *Client {
+	return &Client{
+		cfg:    cfg,
+		logger: logger,
+		client: client,
+		keys:   newKeyPool(cfg.APIKeys, cfg.DailyLimit, store, logger),
+	}
+}
+
+// BreakerState reports the circuit breaker state of the outbound client.
+func (c *Client) BreakerState() httpclient.State {
+	return c.client.BreakerState()
+}
+
+func (c *Client) Name() string {
+	return "omdb"
+}
+
+// pageSize is the fixed number of results OMDb returns per search page.
+const pageSize = 10
+
+// Search queries OMDb for movies by title.
+func (c *Client) Search(ctx context.Context, req domain.MovieSearchRequest) (*domain.MovieSearchResponse, error) {
+	params := url.Values{}
+	params.Set("s", req.Query)
+	params.Set("page", strconv.Itoa(req.Page))
+	if req.Type != "" {
+		params.Set("type", req.Type)
+	}
+	if req.Year != 0 {
+		params.Set("y", strconv.Itoa(req.Year))
+	}
+
+	body, err := c.get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result domain.OMDbSearchResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		c.logger.Error("failed to parse omdb response", zap.Error(err))
+		return nil, appErr.ErrExternalAPI
+	}
+
+	if result.Response == "False" {
+		return nil, appErr.New(404, "no movies found: "+result.Error, appErr.ErrNotFound)
+	}
+
+	total, _ := strconv.Atoi(result.TotalResults)
+	out := &domain.MovieSearchResponse{
+		Results:      make([]domain.SearchResult, 0, len(result.Search)),
+		TotalResults: total,
+		TotalPages:   (total + pageSize - 1) / pageSize,
+		Page:         req.Page,
+	}
+	for _, r := range result.Search {
+		out.Results = append(out.Results, domain.SearchResult{
+			ImdbID:    r.ImdbID,
+			Title:     r.Title,
+			Year:      r.Year,
+			Type:      r.Type,
+			PosterURL: r.Poster,
+		})
+	}
+	return out, nil
+}
+
+// GetByID fetches full movie details. OMDb is keyed by IMDb ID natively.
+func (c *Client) GetByID(ctx context.Context, id string) (*domain.OMDbMovieDetail, error) {
+	params := url.Values{}
+	params.Set("i", id)
+	params.Set("plot", "full")
+
+	body, err := c.get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var detail domain.OMDbMovieDetail
+	if err := json.Unmarshal(body, &detail); err != nil {
+		c.logger.Error("failed to parse omdb response", zap.Error(err))
+		return nil, appErr.ErrExternalAPI
+	}
+
+	if detail.Response == "False" {
+		return nil, appErr.New(404, "movie not found: "+detail.Error, appErr.ErrNotFound)
+	}
+
+	return &detail, nil
+}
+
+// GetByExternalID is equivalent to GetByID for OMDb.
+func (c *Client) GetByExternalID(ctx context.Context, imdbID string) (*domain.OMDbMovieDetail, error) {
+	return c.GetByID(ctx, imdbID)
+}
+
+// GetSeason lists the episodes of one season of a series.
+func (c *Client) GetSeason(ctx context.Context, imdbID string, season int) (*domain.OMDbSeasonResponse, error) {
+	params := url.Values{}
+	params.Set("i", imdbID)
+	params.Set("Season", strconv.Itoa(season))
+
+	body, err := c.get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result domain.OMDbSeasonResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		c.logger.Error("failed to parse omdb response", zap.Error(err))
+		return nil, appErr.ErrExternalAPI
+	}
+
+	if result.Response == "False" {
+		return nil, appErr.New(404, "season not found: "+result.Error, appErr.ErrNotFound)
+	}
+
+	return &result, nil
+}
+
+// Quota reports today's usage of each configured API key.
+func (c *Client) Quota(ctx context.Context) ([]domain.APIKeyQuota, error) {
+	return c.keys.quota(ctx)
+}
+
+// get performs a GET against the OMDb root endpoint and returns the raw body.
+// When OMDb rejects the current key for quota or validity reasons the request
+// is retried with the next key in the pool.
+func (c *Client) get(ctx context.Context, params url.Values) ([]byte, error) {
+	for {
+		i, ok := c.keys.pick(ctx)
+		if !ok {
+			c.logger.Warn("all omdb api keys are exhausted for today")
+			return nil, appErr.ErrExternalAPI
+		}
+
+		body, rejected, err := c.getWithKey(ctx, i, params)
+		if err != nil {
+			return nil, err
+		}
+		if rejected != "" {
+			c.keys.markExhausted(i, rejected)
+			continue
+		}
+		return body, nil
+	}
+}
+
+// getWithKey performs one request using key i. rejected is set to OMDb's
+// error message when the key itself was refused.
+func (c *Client) getWithKey(ctx context.Context, i int, params url.Values) (body []byte, rejected string, err error) {
+	params.Set("apikey", c.keys.keys[i])
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+"/?"+params.Encode(), nil)
+	if err != nil {
+		c.logger.Error("failed to build omdb request", zap.String("error", c.keys.redact(err.Error())))
+		return nil, "", appErr.ErrExternalAPI
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			c.logger.Warn("omdb circuit open, failing fast")
+		} else {
+			c.logger.Error("omdb api call failed", zap.String("error", c.keys.redact(err.Error())))
+		}
+		return nil, "", appErr.ErrExternalAPI
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("failed to read omdb response", zap.String("error", c.keys.redact(err.Error())))
+		return nil, "", appErr.ErrExternalAPI
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		var status struct {
+			Error string `json:"Error"`
+		}
+		_ = json.Unmarshal(body, &status)
+		if status.Error == errLimitReached || status.Error == errInvalidAPIKey {
+			return nil, status.Error, nil
+		}
+		c.logger.Error("omdb api returned error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("error", c.keys.redact(strings.TrimSpace(status.Error))),
+		)
+		return nil, "", appErr.ErrExternalAPI
+	}
+
+	c.keys.record(ctx, i)
+	return body, "", nil
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/config"
+	"github.com/namru/movie-recommend/internal/domain"
+	"github.com/namru/movie-recommend/internal/provider/omdb"
+	"github.com/namru/movie-recommend/internal/provider/tmdb"
+	"github.com/namru/movie-recommend/pkg/httpclient"
+)
+
+// MovieProvider abstracts an external movie metadata source (OMDb, TMDb, ...).
+// Implementations normalize their responses into the OMDb-shaped domain types
+// so the service layer does not care which backend is in use.
+type MovieProvider interface {
+	// Name returns a short identifier such as "omdb" or "tmdb".
+	Name() string
+	// Search looks up movies by title, optionally filtered by type and year.
+	Search(ctx context.Context, req domain.MovieSearchRequest) (*domain.MovieSearchResponse, error)
+	// GetByID fetches full details using the provider's own identifier.
+	GetByID(ctx context.Context, id string) (*domain.OMDbMovieDetail, error)
+	// GetByExternalID fetches full details using an IMDb ID.
+	GetByExternalID(ctx context.Context, imdbID string) (*domain.OMDbMovieDetail, error)
+}
+
+// New builds the provider selected by name ("omdb" or "tmdb"). usage records
+// per-key request counts for providers with metered keys; it may be nil.
+func New(name string, cfg *config.Config, usage omdb.UsageStore, logger *zap.Logger) (MovieProvider, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "omdb":
+		if len(cfg.OMDB.APIKeys) == 0 {
+			return nil, fmt.Errorf("omdb provider requires OMDB_API_KEY or OMDB_API_KEYS")
+		}
+		return omdb.New(&cfg.OMDB, newHTTPClient(cfg), usage, logger), nil
+	case "tmdb":
+		return tmdb.New(&cfg.TMDB, newHTTPClient(cfg), logger), nil
+	default:
+		return nil, fmt.Errorf("unknown movie provider %q", name)
+	}
+}
+
+// newHTTPClient builds an outbound client with its own circuit breaker, so one
+// provider being down never trips another provider's breaker.
+func newHTTPClient(cfg *config.Config) *httpclient.Client {
+	return httpclient.New(httpclient.Config{
+		Timeout:          cfg.Outbound.Timeout,
+		MaxRetries:       cfg.Outbound.MaxRetries,
+		BaseBackoff:      cfg.Outbound.BaseBackoff,
+		MaxBackoff:       cfg.Outbound.MaxBackoff,
+		BreakerThreshold: cfg.Outbound.BreakerThreshold,
+		BreakerCooldown:  cfg.Outbound.BreakerCooldown,
+	})
+}
+
+// NewFromConfig builds the primary provider followed by its configured fallbacks.
+func NewFromConfig(cfg *config.Config, usage omdb.UsageStore, logger *zap.Logger) (*Chain, error) {
+	names := append([]string{cfg.Provider.Name}, cfg.Provider.Fallbacks...)
+	seen := make(map[string]bool, len(names))
+
+	var providers []MovieProvider
+	for _, name := range names {
+		p, err := New(name, cfg, usage, logger)
+		if err != nil {
+			return nil, err
+		}
+		if seen[p.Name()] {
+			continue
+		}
+		seen[p.Name()] = true
+		providers = append(providers, p)
+	}
+
+	return NewChain(providers, cfg.Provider.Cooldown, logger), nil
+}
@@ -0,0 +1,482 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/config"
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/pkg/httpclient"
+)
+
+// maxConcurrentLookups bounds the external_ids calls made per search page.
+const maxConcurrentLookups = 5
+
+// Client implements provider.MovieProvider against the TMDb v3 API.
+type Client struct {
+	cfg    *config.TMDBConfig
+	logger *zap.Logger
+	client *httpclient.Client
+}
+
+func New(cfg *config.TMDBConfig, client *httpclient.Client, logger *zap.Logger) *Client {
+	return &Client{
+		cfg:    cfg,
+		logger: logger,
+		client: client,
+	}
+}
+
+// BreakerState reports the circuit breaker state of the outbound client.
+func (c *Client) BreakerState() httpclient.State {
+	return c.client.BreakerState()
+}
+
+func (c *Client) Name() string {
+	return "tmdb"
+}
+
+// ---------- TMDb response types ----------
+
+type searchResponse struct {
+	Page         int           `json:"page"`
+	Results      []searchMovie `json:"results"`
+	TotalResults int           `json:"total_results"`
+	TotalPages   int           `json:"total_pages"`
+}
+
+// searchMovie is a movie or TV search hit; TV shows use name/first_air_date.
+type searchMovie struct {
+	ID           int    `json:"id"`
+	Title        string `json:"title"`
+	Name         string `json:"name"`
+	ReleaseDate  string `json:"release_date"`
+	FirstAirDate string `json:"first_air_date"`
+	PosterPath   string `json:"poster_path"`
+}
+
+func (m *searchMovie) title() string {
+	if m.Title != "" {
+		return m.Title
+	}
+	return m.Name
+}
+
+func (m *searchMovie) releaseDate() string {
+	if m.ReleaseDate != "" {
+		return m.ReleaseDate
+	}
+	return m.FirstAirDate
+}
+
+type externalIDs struct {
+	ImdbID string `json:"imdb_id"`
+}
+
+type findResponse struct {
+	MovieResults []searchMovie `json:"movie_results"`
+	TVResults    []searchMovie `json:"tv_results"`
+}
+
+type named struct {
+	Name        string `json:"name"`
+	EnglishName string `json:"english_name"`
+}
+
+type castMember struct {
+	Name  string `json:"name"`
+	Order int    `json:"order"`
+}
+
+type crewMember struct {
+	Name       string `json:"name"`
+	Job        string `json:"job"`
+	Department string `json:"department"`
+}
+
+type movieDetail struct {
+	ID                  int     `json:"id"`
+	ImdbID              string  `json:"imdb_id"`
+	Title               string  `json:"title"`
+	ReleaseDate         string  `json:"release_date"`
+	Runtime             int     `json:"runtime"`
+	Overview            string  `json:"overview"`
+	PosterPath          string  `json:"poster_path"`
+	Genres              []named `json:"genres"`
+	SpokenLanguages     []named `json:"spoken_languages"`
+	ProductionCountries []named `json:"production_countries"`
+	Credits             struct {
+		Cast []castMember `json:"cast"`
+		Crew []crewMember `json:"crew"`
+	} `json:"credits"`
+}
+
+type tvDetail struct {
+	ID                  int     `json:"id"`
+	Name                string  `json:"name"`
+	FirstAirDate        string  `json:"first_air_date"`
+	EpisodeRunTime      []int   `json:"episode_run_time"`
+	NumberOfSeasons     int     `json:"number_of_seasons"`
+	Overview            string  `json:"overview"`
+	PosterPath          string  `json:"poster_path"`
+	Genres              []named `json:"genres"`
+	SpokenLanguages     []named `json:"spoken_languages"`
+	ProductionCountries []named `json:"production_countries"`
+	CreatedBy           []named `json:"created_by"`
+	Credits             struct {
+		Cast []castMember `json:"cast"`
+		Crew []crewMember `json:"crew"`
+	} `json:"credits"`
+}
+
+// ---------- MovieProvider ----------
+
+// Search queries TMDb by title. TMDb search results do not carry IMDb IDs,
+// so each hit is resolved through the external_ids endpoint and hits without
+// one are dropped. Type "series" searches TV shows; episodes are not
+// searchable on TMDb.
+func (c *Client) Search(ctx context.Context, req domain.MovieSearchRequest) (*domain.MovieSearchResponse, error) {
+	kind, resultType := "movie", "movie"
+	switch req.Type {
+	case "series":
+		kind, resultType = "tv", "series"
+	case "episode":
+		return nil, appErr.New(400, "episode search is not supported by tmdb", appErr.ErrBadRequest)
+	}
+
+	params := url.Values{}
+	params.Set("query", req.Query)
+	params.Set("page", strconv.Itoa(req.Page))
+	if req.Year != 0 {
+		if kind == "tv" {
+			params.Set("first_air_date_year", strconv.Itoa(req.Year))
+		} else {
+			params.Set("primary_release_year", strconv.Itoa(req.Year))
+		}
+	}
+
+	var sr searchResponse
+	if err := c.get(ctx, "/search/"+kind, params, &sr); err != nil {
+		return nil, err
+	}
+
+	if len(sr.Results) == 0 {
+		return nil, appErr.New(404, "no movies found: Movie not found!", appErr.ErrNotFound)
+	}
+
+	imdbIDs := make([]string, len(sr.Results))
+	lookupErrs := make([]error, len(sr.Results))
+	sem := make(chan struct{}, maxConcurrentLookups)
+	var wg sync.WaitGroup
+	for i, m := range sr.Results {
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var ext externalIDs
+			if err := c.get(ctx, fmt.Sprintf("/%s/%d/external_ids", kind, id), nil, &ext); err != nil {
+				lookupErrs[i] = err
+				return
+			}
+			imdbIDs[i] = ext.ImdbID
+		}(i, m.ID)
+	}
+	wg.Wait()
+
+	// A hit whose lookup failed upstream is not a hit without an IMDb ID:
+	// report the outage so the chain can fall back instead of answering 404.
+	failed := 0
+	for _, err := range lookupErrs {
+		if err == nil {
+			continue
+		}
+		failed++
+		if !errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.ErrExternalAPI
+		}
+	}
+	if failed == len(sr.Results) {
+		return nil, appErr.ErrExternalAPI
+	}
+
+	result := &domain.MovieSearchResponse{
+		Results:    make([]domain.SearchResult, 0, len(sr.Results)),
+		TotalPages: sr.TotalPages,
+		Page:       req.Page,
+	}
+	dropped := 0
+	for i, m := range sr.Results {
+		if imdbIDs[i] == "" {
+			dropped++
+			continue
+		}
+		result.Results = append(result.Results, domain.SearchResult{
+			ImdbID:    imdbIDs[i],
+			Title:     m.title(),
+			Year:      yearOf(m.releaseDate()),
+			Type:      resultType,
+			PosterURL: c.posterURL(m.PosterPath),
+		})
+	}
+
+	if len(result.Results) == 0 {
+		return nil, appErr.New(404, "no movies found: Movie not found!", appErr.ErrNotFound)
+	}
+	// Hits without an IMDb ID can never be returned, so they are not counted.
+	result.TotalResults = sr.TotalResults - dropped
+	if result.TotalResults < len(result.Results) {
+		result.TotalResults = len(result.Results)
+	}
+
+	return result, nil
+}
+
+// GetByID fetches full movie details using a TMDb numeric ID.
+func (c *Client) GetByID(ctx context.Context, id string) (*domain.OMDbMovieDetail, error) {
+	params := url.Values{}
+	params.Set("append_to_response", "credits")
+
+	var md movieDetail
+	if err := c.get(ctx, "/movie/"+url.PathEscape(id), params, &md); err != nil {
+		return nil, err
+	}
+
+	return c.toDetail(&md), nil
+}
+
+// GetByExternalID resolves an IMDb ID through /find and then fetches details.
+// Movies are preferred; TV shows are fetched as series.
+func (c *Client) GetByExternalID(ctx context.Context, imdbID string) (*domain.OMDbMovieDetail, error) {
+	params := url.Values{}
+	params.Set("external_source", "imdb_id")
+
+	var fr findResponse
+	if err := c.get(ctx, "/find/"+url.PathEscape(imdbID), params, &fr); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(fr.MovieResults) > 0:
+		return c.GetByID(ctx, strconv.Itoa(fr.MovieResults[0].ID))
+	case len(fr.TVResults) > 0:
+		return c.getSeries(ctx, fr.TVResults[0].ID, imdbID)
+	default:
+		return nil, appErr.New(404, "movie not found: Incorrect IMDb ID.", appErr.ErrNotFound)
+	}
+}
+
+// getSeries fetches a TV show by TMDb ID. TV details do not carry the IMDb
+// ID, so the one it was resolved from is passed in.
+func (c *Client) getSeries(ctx context.Context, id int, imdbID string) (*domain.OMDbMovieDetail, error) {
+	params := url.Values{}
+	params.Set("append_to_response", "credits")
+
+	var td tvDetail
+	if err := c.get(ctx, "/tv/"+strconv.Itoa(id), params, &td); err != nil {
+		return nil, err
+	}
+
+	return c.toSeriesDetail(&td, imdbID), nil
+}
+
+// ---------- helpers ----------
+
+// get performs a GET against the TMDb API and decodes the JSON body into out.
+func (c *Client) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("api_key", c.cfg.APIKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		c.logger.Error("failed to build tmdb request", zap.Error(err))
+		return appErr.ErrExternalAPI
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) {
+			c.logger.Warn("tmdb circuit open, failing fast")
+		} else {
+			c.logger.Error("tmdb api call failed", zap.Error(err))
+		}
+		return appErr.ErrExternalAPI
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("failed to read tmdb response", zap.Error(err))
+		return appErr.ErrExternalAPI
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return appErr.New(404, "movie not found", appErr.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Error("tmdb api returned error",
+			zap.Int("status", resp.StatusCode),
+			zap.String("path", path),
+		)
+		return appErr.ErrExternalAPI
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		c.logger.Error("failed to parse tmdb response", zap.Error(err))
+		return appErr.ErrExternalAPI
+	}
+	return nil
+}
+
+// toDetail maps a TMDb movie onto the OMDb-shaped detail used by the services.
+func (c *Client) toDetail(md *movieDetail) *domain.OMDbMovieDetail {
+	var directors, writers []string
+	for _, crew := range md.Credits.Crew {
+		switch {
+		case crew.Job == "Director":
+			directors = append(directors, crew.Name)
+		case crew.Department == "Writing":
+			writers = append(writers, crew.Name)
+		}
+	}
+
+	cast := md.Credits.Cast
+	sort.Slice(cast, func(i, j int) bool { return cast[i].Order < cast[j].Order })
+	var actors []string
+	for i := 0; i < len(cast) && i < 4; i++ {
+		actors = append(actors, cast[i].Name)
+	}
+
+	detail := &domain.OMDbMovieDetail{
+		Title:      md.Title,
+		Year:       yearOf(md.ReleaseDate),
+		Released:   releasedOf(md.ReleaseDate),
+		Genre:      orNA(joinNames(md.Genres, false)),
+		Director:   orNA(strings.Join(directors, ", ")),
+		Writer:     orNA(strings.Join(dedupe(writers), ", ")),
+		Actors:     orNA(strings.Join(actors, ", ")),
+		Plot:       orNA(md.Overview),
+		Language:   orNA(joinNames(md.SpokenLanguages, true)),
+		Country:    orNA(joinNames(md.ProductionCountries, false)),
+		Poster:     c.posterURL(md.PosterPath),
+		ImdbRating: "N/A", // TMDb has no IMDb rating; its vote average is a different score
+		ImdbID:     md.ImdbID,
+		Type:       "movie",
+		Response:   "True",
+	}
+	if md.Runtime > 0 {
+		detail.Runtime = fmt.Sprintf("%d min", md.Runtime)
+	} else {
+		detail.Runtime = "N/A"
+	}
+	return detail
+}
+
+// toSeriesDetail maps a TMDb TV show onto the OMDb-shaped detail. Writers
+// are taken from the show's creators, as OMDb does for series.
+func (c *Client) toSeriesDetail(td *tvDetail, imdbID string) *domain.OMDbMovieDetail {
+	cast := td.Credits.Cast
+	sort.Slice(cast, func(i, j int) bool { return cast[i].Order < cast[j].Order })
+	var actors []string
+	for i := 0; i < len(cast) && i < 4; i++ {
+		actors = append(actors, cast[i].Name)
+	}
+
+	detail := &domain.OMDbMovieDetail{
+		Title:      td.Name,
+		Year:       yearOf(td.FirstAirDate),
+		Released:   releasedOf(td.FirstAirDate),
+		Genre:      orNA(joinNames(td.Genres, false)),
+		Director:   "N/A",
+		Writer:     orNA(joinNames(td.CreatedBy, false)),
+		Actors:     orNA(strings.Join(actors, ", ")),
+		Plot:       orNA(td.Overview),
+		Language:   orNA(joinNames(td.SpokenLanguages, true)),
+		Country:    orNA(joinNames(td.ProductionCountries, false)),
+		Poster:     c.posterURL(td.PosterPath),
+		ImdbRating: "N/A",
+		ImdbID:     imdbID,
+		Type:       "series",
+		Runtime:    "N/A",
+		Response:   "True",
+	}
+	if len(td.EpisodeRunTime) > 0 && td.EpisodeRunTime[0] > 0 {
+		detail.Runtime = fmt.Sprintf("%d min", td.EpisodeRunTime[0])
+	}
+	if td.NumberOfSeasons > 0 {
+		detail.TotalSeasons = strconv.Itoa(td.NumberOfSeasons)
+	}
+	return detail
+}
+
+func (c *Client) posterURL(path string) string {
+	if path == "" {
+		return "N/A"
+	}
+	return c.cfg.ImageBaseURL + path
+}
+
+// yearOf extracts the year from a TMDb "YYYY-MM-DD" date.
+func yearOf(date string) string {
+	if len(date) < 4 {
+		return ""
+	}
+	return date[:4]
+}
+
+// releasedOf converts "YYYY-MM-DD" into OMDb's "02 Jan 2006" format.
+func releasedOf(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "N/A"
+	}
+	return t.Format("02 Jan 2006")
+}
+
+func joinNames(items []named, english bool) string {
+	names := make([]string, 0, len(items))
+	for _, it := range items {
+		name := it.Name
+		if english && it.EnglishName != "" {
+			name = it.EnglishName
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := names[:0]
+	for _, n := range names {
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	return out
+}
+
+func orNA(s string) string {
+	if s == "" {
+		return "N/A"
+	}
+	return s
+}
@@ -21,28 +21,120 @@ type MovieRepository interface {
 	Create(ctx context.Context, movie *domain.Movie) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Movie, error)
 	GetByImdbID(ctx context.Context, imdbID string) (*domain.Movie, error)
+	GetByImdbIDs(ctx context.Context, imdbIDs []string) ([]domain.Movie, error)
 	GetByGenre(ctx context.Context, genre string, limit int) ([]domain.Movie, error)
+	SaveCredits(ctx context.Context, movieID uuid.UUID, genres []string, credits []domain.Credit) error
+	Search(ctx context.Context, query string, limit, offset int) ([]domain.Movie, int, error)
+	List(ctx context.Context, q domain.MovieListQuery) ([]domain.CatalogMovie, error)
+	SimilarCandidates(ctx context.Context, movieID uuid.UUID, limit int) (*domain.SimilaritySource, []domain.SimilarityCandidate, error)
+	MarkRefreshed(ctx context.Context, id uuid.UUID, at time.Time) error
+	MarkRefreshAttempted(ctx context.Context, id uuid.UUID, at time.Time) error
+	SaveEdit(ctx context.Context, edit domain.MovieEdit) error
+	// SaveRefresh applies edit only if the movie's updated_at still equals
+	// seen, reporting whether it did.
+	SaveRefresh(ctx context.Context, edit domain.MovieEdit, seen time.Time) (bool, error)
+	SetHidden(ctx context.Context, id uuid.UUID, hidden bool, audit *domain.MovieAuditEntry) error
+	ListHiddenImdbIDs(ctx context.Context, imdbIDs []string) ([]string, error)
+	SetLockedFields(ctx context.Context, id uuid.UUID, fields []string, audit *domain.MovieAuditEntry) error
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID, audit *domain.MovieAuditEntry) (*domain.MergeResult, error)
+	ListStale(ctx context.Context, olderThan time.Time, limit int) ([]domain.Movie, error)
+	ImportBatch(ctx context.Context, batch []domain.MovieImport) (int, error)
+	ListTitles(ctx context.Context, afterImdbID string, limit int) ([]domain.TitleSuggestion, error)
 }
 
 // WatchlistRepository defines persistence operations for watchlists.
 type WatchlistRepository interface {
 	Create(ctx context.Context, entry *domain.Watchlist) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Watchlist, error)
+	List(ctx context.Context, q domain.WatchlistQuery) ([]domain.Watchlist, int, error)
+	ListMovieIDs(ctx context.Context, listID uuid.UUID) ([]uuid.UUID, error)
 	Update(ctx context.Context, id uuid.UUID, status domain.WatchlistStatus) error
+	UpdateDetails(ctx context.Context, id uuid.UUID, priority domain.WatchlistPriority, note string) error
+	Move(ctx context.Context, listID, entryID uuid.UUID, afterID *uuid.UUID) (float64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	Exists(ctx context.Context, userID, movieID uuid.UUID) (bool, error)
+	Exists(ctx context.Context, listID, movieID uuid.UUID) (bool, error)
+	GetByUserAndMovie(ctx context.Context, userID, movieID uuid.UUID) (*domain.Watchlist, error)
+	CountByStatus(ctx context.Context, movieID uuid.UUID) (map[domain.WatchlistStatus]int, error)
+}
+
+// ListRepository defines persistence operations for named watchlists.
+type ListRepository interface {
+	Create(ctx context.Context, list *domain.UserList) error
+	EnsureDefault(ctx context.Context, userID uuid.UUID) (*domain.UserList, error)
+	GetDefault(ctx context.Context, userID uuid.UUID) (*domain.UserList, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.UserList, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserList, error)
+	Rename(ctx context.Context, id uuid.UUID, name string) error
+	Reorder(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TagRepository defines persistence operations for user tags and their links
+// to watchlist entries and ratings.
+type TagRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Tag, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error)
+	SetWatchlistTags(ctx context.Context, userID, entryID uuid.UUID, names []string) error
+	SetRatingTags(ctx context.Context, userID, ratingID uuid.UUID, names []string) error
+	Rename(ctx context.Context, id uuid.UUID, name string) error
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// WatchEventRepository defines persistence operations for the watch history.
+type WatchEventRepository interface {
+	Create(ctx context.Context, event *domain.WatchEvent) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.WatchEvent, error)
+	Update(ctx context.Context, event *domain.WatchEvent) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, q domain.WatchHistoryQuery) ([]domain.WatchEvent, error)
+}
+
+// AuditRepository reads the admin curation log. Entries are written by the
+// MovieRepository curation methods, in the transaction of the change.
+type AuditRepository interface {
+	ListByMovie(ctx context.Context, movieID uuid.UUID, limit int) ([]domain.MovieAuditEntry, error)
+}
+
+// EpisodeRepository defines persistence operations for series episodes and
+// per-watchlist episode progress.
+type EpisodeRepository interface {
+	UpsertSeason(ctx context.Context, seriesID uuid.UUID, episodes []domain.Episode) error
+	GetBySeries(ctx context.Context, seriesID uuid.UUID) ([]domain.Episode, error)
+	MarkWatched(ctx context.Context, watchlistID, episodeID uuid.UUID, at time.Time) error
+	UnmarkWatched(ctx context.Context, watchlistID, episodeID uuid.UUID) error
+	GetWatched(ctx context.Context, watchlistID uuid.UUID) (map[uuid.UUID]time.Time, error)
 }
 
 // RatingRepository defines persistence operations for ratings.
 type RatingRepository interface {
 	Create(ctx context.Context, rating *domain.Rating) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Rating, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Rating, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, tags []string) ([]domain.Rating, error)
 	Update(ctx context.Context, rating *domain.Rating) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetTopGenresByUser(ctx context.Context, userID uuid.UUID, minScore int, limit int) ([]string, error)
 	GetRatedMovieIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetByUserAndMovie(ctx context.Context, userID, movieID uuid.UUID) (*domain.Rating, error)
+	GetScoreHistogram(ctx context.Context, movieID uuid.UUID) (map[int]int, error)
+}
+
+// ActivityRepository aggregates user activity across ratings and watchlists.
+type ActivityRepository interface {
+	ScoreMovies(ctx context.Context, since time.Time, halfLife time.Duration, limit int) ([]domain.ScoredID, error)
+}
+
+// SuggestionRepository stores the title autocomplete prefix index.
+type SuggestionRepository interface {
+	Index(ctx context.Context, entries []domain.SuggestionEntry) error
+	Remove(ctx context.Context, imdbID string, prefixes []string) error
+	Suggest(ctx context.Context, prefix string, limit int) ([]domain.TitleSuggestion, error)
+	// BeginRebuild starts an empty copy of the index and returns its
+	// generation; IndexRebuild fills it and CommitRebuild swaps it in,
+	// discarding the previous index.
+	BeginRebuild(ctx context.Context) (string, error)
+	IndexRebuild(ctx context.Context, gen string, entries []domain.SuggestionEntry) error
+	CommitRebuild(ctx context.Context, gen string) error
 }
 
 // CacheRepository defines caching operations.
@@ -50,4 +142,16 @@ type CacheRepository interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value string, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// Incr atomically increments key, setting ttl when the key is created.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// AcquireLock sets key to token only if it is absent. It reports whether
+	// the lock was taken.
+	AcquireLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+	// ReleaseLock deletes key only if it still holds token.
+	ReleaseLock(ctx context.Context, key string, token string) error
+	// ReplaceSortedSet atomically replaces the sorted set at key with members.
+	// An empty members slice deletes the key.
+	ReplaceSortedSet(ctx context.Context, key string, members []domain.ScoredID, ttl time.Duration) error
+	// TopSortedSet returns up to limit members of key, highest score first.
+	TopSortedSet(ctx context.Context, key string, limit int) ([]domain.ScoredID, error)
 }
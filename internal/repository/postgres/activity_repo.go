@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+// ActivityRepo scores movies by recent user activity recorded in the
+// ratings and watchlists tables.
+type ActivityRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewActivityRepo(pool *pgxpool.Pool) *ActivityRepo {
+	return &ActivityRepo{pool: pool}
+}
+
+// ScoreMovies returns the top movies by activity since the given time, keyed
+// by IMDb ID. A watchlist addition weighs 1 (once per user, however many of
+// their lists it is on) and a rating weighs score/5, so a 10 counts double and
+// a 1 barely registers. Each event then decays exponentially with its age
+// using the given half-life.
+func (r *ActivityRepo) ScoreMovies(ctx context.Context, since time.Time, halfLife time.Duration, limit int) ([]domain.ScoredID, error) {
+	query := `
+		WITH events AS (
+			SELECT movie_id, score / 5.0 AS weight, updated_at AS at
+			FROM ratings
+			WHERE updated_at >= $1
+			UNION ALL
+			SELECT movie_id, 1.0, MIN(added_at)
+			FROM watchlists
+			WHERE added_at >= $1
+			GROUP BY user_id, movie_id
+		)
+		SELECT m.imdb_id,
+		       SUM(e.weight * exp(-ln(2) * extract(epoch FROM now() - e.at) / $2::float8))::float8 AS score
+		FROM events e
+		JOIN movies m ON m.id = e.movie_id
+		WHERE NOT m.hidden
+		GROUP BY m.imdb_id
+		ORDER BY score DESC, m.imdb_id
+		LIMIT $3`
+
+	rows, err := r.pool.Query(ctx, query, since, halfLife.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []domain.ScoredID
+	for rows.Next() {
+		var s domain.ScoredID
+		if err := rows.Scan(&s.ID, &s.Score); err != nil {
+			return nil, err
+		}
+		scores = append(scores, s)
+	}
+	return scores, rows.Err()
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+type AuditRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepo(pool *pgxpool.Pool) *AuditRepo {
+	return &AuditRepo{pool: pool}
+}
+
+// insertAudit writes an audit entry inside the transaction that applies the
+// change it records. MovieRepo's curation methods call it.
+func insertAudit(ctx context.Context, q execer, entry *domain.MovieAuditEntry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO movie_audit_log (id, movie_id, imdb_id, admin_id, action, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = q.Exec(ctx, query,
+		entry.ID, entry.MovieID, entry.ImdbID, entry.AdminID,
+		entry.Action, changes, entry.CreatedAt,
+	)
+	return err
+}
+
+// ListByMovie returns the newest audit entries for a movie, including those
+// of duplicates merged into it.
+func (r *AuditRepo) ListByMovie(ctx context.Context, movieID uuid.UUID, limit int) ([]domain.MovieAuditEntry, error) {
+	query := `
+		SELECT id, movie_id, imdb_id, admin_id, action, changes, created_at
+		FROM movie_audit_log
+		WHERE movie_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, movieID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.MovieAuditEntry
+	for rows.Next() {
+		var e domain.MovieAuditEntry
+		var changes []byte
+		if err := rows.Scan(&e.ID, &e.MovieID, &e.ImdbID, &e.AdminID, &e.Action, &changes, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+type EpisodeRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewEpisodeRepo(pool *pgxpool.Pool) *EpisodeRepo {
+	return &EpisodeRepo{pool: pool}
+}
+
+// UpsertSeason inserts or updates the given episodes of a series, keyed by
+// season and episode number.
+func (r *EpisodeRepo) UpsertSeason(ctx context.Context, seriesID uuid.UUID, episodes []domain.Episode) error {
+	query := `
+		INSERT INTO episodes (id, series_id, season, episode, imdb_id, title, released, imdb_rating)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (series_id, season, episode) DO UPDATE SET
+			imdb_id = EXCLUDED.imdb_id,
+			title = EXCLUDED.title,
+			released = EXCLUDED.released,
+			imdb_rating = EXCLUDED.imdb_rating`
+
+	batch := &pgx.Batch{}
+	for _, e := range episodes {
+		batch.Queue(query, e.ID, seriesID, e.Season, e.Episode, e.ImdbID, e.Title, e.Released, e.ImdbRating)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+// GetBySeries returns every known episode of a series in broadcast order.
+func (r *EpisodeRepo) GetBySeries(ctx context.Context, seriesID uuid.UUID) ([]domain.Episode, error) {
+	query := `
+		SELECT id, series_id, season, episode, COALESCE(imdb_id, ''), title, released, COALESCE(imdb_rating, '')
+		FROM episodes
+		WHERE series_id = $1
+		ORDER BY season, episode`
+
+	rows, err := r.pool.Query(ctx, query, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var episodes []domain.Episode
+	for rows.Next() {
+		var e domain.Episode
+		if err := rows.Scan(
+			&e.ID, &e.SeriesID, &e.Season, &e.Episode, &e.ImdbID, &e.Title, &e.Released, &e.ImdbRating,
+		); err != nil {
+			return nil, err
+		}
+		episodes = append(episodes, e)
+	}
+	return episodes, rows.Err()
+}
+
+// MarkWatched records an episode as watched; marking it again is a no-op.
+func (r *EpisodeRepo) MarkWatched(ctx context.Context, watchlistID, episodeID uuid.UUID, at time.Time) error {
+	query := `
+		INSERT INTO episode_progress (watchlist_id, episode_id, watched_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+	_, err := r.pool.Exec(ctx, query, watchlistID, episodeID, at)
+	return err
+}
+
+func (r *EpisodeRepo) UnmarkWatched(ctx context.Context, watchlistID, episodeID uuid.UUID) error {
+	query := `DELETE FROM episode_progress WHERE watchlist_id = $1 AND episode_id = $2`
+	_, err := r.pool.Exec(ctx, query, watchlistID, episodeID)
+	return err
+}
+
+// GetWatched returns the watched episodes of a watchlist entry and when each
+// was watched.
+func (r *EpisodeRepo) GetWatched(ctx context.Context, watchlistID uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	query := `SELECT episode_id, watched_at FROM episode_progress WHERE watchlist_id = $1`
+
+	rows, err := r.pool.Query(ctx, query, watchlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	watched := make(map[uuid.UUID]time.Time)
+	for rows.Next() {
+		var id uuid.UUID
+		var at time.Time
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, err
+		}
+		watched[id] = at
+	}
+	return watched, rows.Err()
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+)
+
+// listColumns selects a user_lists row aliased l with its entry count.
+const listColumns = `l.id, l.user_id, l.name, l.position, l.is_default, l.created_at,
+		       (SELECT COUNT(*) FROM watchlists w WHERE w.list_id = l.id)`
+
+func listFields(l *domain.UserList) []interface{} {
+	return []interface{}{&l.ID, &l.UserID, &l.Name, &l.Position, &l.IsDefault, &l.CreatedAt, &l.EntryCount}
+}
+
+type ListRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewListRepo(pool *pgxpool.Pool) *ListRepo {
+	return &ListRepo{pool: pool}
+}
+
+// Create inserts a list after the user's existing ones.
+func (r *ListRepo) Create(ctx context.Context, list *domain.UserList) error {
+	query := `
+		INSERT INTO user_lists (id, user_id, name, position, is_default, created_at)
+		VALUES ($1, $2, $3,
+		        (SELECT COALESCE(MAX(position) + 1, 0) FROM user_lists WHERE user_id = $2),
+		        $4, $5)
+		RETURNING position`
+
+	err := r.pool.QueryRow(ctx, query,
+		list.ID, list.UserID, list.Name, list.IsDefault, list.CreatedAt,
+	).Scan(&list.Position)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return appErr.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// EnsureDefault returns the user's default list, creating it first if the
+// user has none yet.
+func (r *ListRepo) EnsureDefault(ctx context.Context, userID uuid.UUID) (*domain.UserList, error) {
+	list, err := r.GetDefault(ctx, userID)
+	if !errors.Is(err, appErr.ErrNotFound) {
+		return list, err
+	}
+
+	// Concurrent callers race on the partial unique index; the loser's
+	// insert is a no-op and both read back the same row.
+	query := `
+		INSERT INTO user_lists (user_id, name, position, is_default)
+		VALUES ($1, $2, 0, TRUE)
+		ON CONFLICT DO NOTHING`
+	if _, err := r.pool.Exec(ctx, query, userID, domain.DefaultListName); err != nil {
+		return nil, err
+	}
+	return r.GetDefault(ctx, userID)
+}
+
+func (r *ListRepo) GetDefault(ctx context.Context, userID uuid.UUID) (*domain.UserList, error) {
+	query := `SELECT ` + listColumns + ` FROM user_lists l WHERE l.user_id = $1 AND l.is_default`
+	return r.scanOne(ctx, query, userID)
+}
+
+func (r *ListRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.UserList, error) {
+	query := `SELECT ` + listColumns + ` FROM user_lists l WHERE l.id = $1`
+	return r.scanOne(ctx, query, id)
+}
+
+func (r *ListRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserList, error) {
+	query := `
+		SELECT ` + listColumns + `
+		FROM user_lists l
+		WHERE l.user_id = $1
+		ORDER BY l.position, l.created_at`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []domain.UserList
+	for rows.Next() {
+		var l domain.UserList
+		if err := rows.Scan(listFields(&l)...); err != nil {
+			return nil, err
+		}
+		lists = append(lists, l)
+	}
+	return lists, rows.Err()
+}
+
+func (r *ListRepo) Rename(ctx context.Context, id uuid.UUID, name string) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE user_lists SET name = $1 WHERE id = $2`, name, id)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return appErr.ErrAlreadyExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+// Reorder sets the position of each of the user's lists to its index in ids.
+func (r *ListRepo) Reorder(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	query := `
+		UPDATE user_lists l
+		SET position = v.ord - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS v(id, ord)
+		WHERE l.id = v.id AND l.user_id = $1`
+
+	_, err := r.pool.Exec(ctx, query, userID, ids)
+	return err
+}
+
+// Delete removes a list together with its entries.
+func (r *ListRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM user_lists WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ListRepo) scanOne(ctx context.Context, query string, arg interface{}) (*domain.UserList, error) {
+	var l domain.UserList
+	if err := r.pool.QueryRow(ctx, query, arg).Scan(listFields(&l)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &l, nil
+}
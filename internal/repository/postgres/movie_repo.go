@@ -3,14 +3,44 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/namru/movie-recommend/internal/domain"
 	appErr "github.com/namru/movie-recommend/internal/errors"
 )
 
+// movieColumns is the movie select list (aliased as m) in the order expected
+// by movieFields. Nullable text columns added after the initial schema are
+// coalesced so they scan into plain strings.
+const movieColumns = `m.id, m.imdb_id, m.title, m.year, m.genre, m.director, m.actors, m.plot, m.poster_url, m.imdb_rating,
+		       COALESCE(m.rated, ''), m.released, m.runtime_minutes, COALESCE(m.writer, ''), COALESCE(m.language, ''),
+		       COALESCE(m.country, ''), COALESCE(m.awards, ''), COALESCE(m.type, ''), m.release_year, m.imdb_score, m.created_at,
+		       m.updated_at, m.refreshed_at, m.total_seasons, m.hidden, m.locked_fields`
+
+// movieFields returns scan destinations matching movieColumns.
+func movieFields(m *domain.Movie) []interface{} {
+	return []interface{}{
+		&m.ID, &m.ImdbID, &m.Title, &m.Year, &m.Genre,
+		&m.Director, &m.Actors, &m.Plot, &m.PosterURL, &m.ImdbRating,
+		&m.Rated, &m.Released, &m.RuntimeMinutes, &m.Writer, &m.Language,
+		&m.Country, &m.Awards, &m.Type, &m.ReleaseYear, &m.ImdbScore, &m.CreatedAt,
+		&m.UpdatedAt, &m.RefreshedAt, &m.TotalSeasons, &m.Hidden, &m.LockedFields,
+	}
+}
+
+// execer is satisfied by both *pgxpool.Pool and pgx.Tx, so a statement can
+// run on its own or as part of a larger transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
 type MovieRepo struct {
 	pool *pgxpool.Pool
 }
@@ -21,28 +51,34 @@ func NewMovieRepo(pool *pgxpool.Pool) *MovieRepo {
 
 func (r *MovieRepo) Create(ctx context.Context, movie *domain.Movie) error {
 	query := `
-		INSERT INTO movies (id, imdb_id, title, year, genre, director, actors, plot, poster_url, imdb_rating, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO movies (id, imdb_id, title, year, genre, director, actors, plot, poster_url, imdb_rating,
+		                    rated, released, runtime_minutes, writer, language, country, awards, type,
+		                    release_year, imdb_score, created_at, updated_at, refreshed_at, total_seasons)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $21, $21, $22)
 		ON CONFLICT (imdb_id) DO NOTHING`
 
-	_, err := r.pool.Exec(ctx, query,
+	tag, err := r.pool.Exec(ctx, query,
 		movie.ID, movie.ImdbID, movie.Title, movie.Year, movie.Genre,
 		movie.Director, movie.Actors, movie.Plot, movie.PosterURL,
-		movie.ImdbRating, movie.CreatedAt,
+		movie.ImdbRating, movie.Rated, movie.Released, movie.RuntimeMinutes,
+		movie.Writer, movie.Language, movie.Country, movie.Awards, movie.Type,
+		movie.ReleaseYear, movie.ImdbScore, movie.CreatedAt, movie.TotalSeasons,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrAlreadyExists
+	}
+	return nil
 }
 
 func (r *MovieRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Movie, error) {
-	query := `SELECT id, imdb_id, title, year, genre, director, actors, plot, poster_url, imdb_rating, created_at
-	           FROM movies WHERE id = $1`
+	query := `SELECT ` + movieColumns + `
+	           FROM movies m WHERE m.id = $1`
 
 	var movie domain.Movie
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&movie.ID, &movie.ImdbID, &movie.Title, &movie.Year, &movie.Genre,
-		&movie.Director, &movie.Actors, &movie.Plot, &movie.PosterURL,
-		&movie.ImdbRating, &movie.CreatedAt,
-	)
+	err := r.pool.QueryRow(ctx, query, id).Scan(movieFields(&movie)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.ErrNotFound
@@ -53,15 +89,16 @@ func (r *MovieRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Movie, e
 }
 
 func (r *MovieRepo) GetByImdbID(ctx context.Context, imdbID string) (*domain.Movie, error) {
-	query := `SELECT id, imdb_id, title, year, genre, director, actors, plot, poster_url, imdb_rating, created_at
-	           FROM movies WHERE imdb_id = $1`
+	// Fall back to aliases left behind by merges.
+	query := `SELECT ` + movieColumns + `
+	           FROM movies m WHERE m.imdb_id = $1
+	           UNION ALL
+	           SELECT ` + movieColumns + `
+	           FROM movie_aliases a JOIN movies m ON m.id = a.movie_id WHERE a.imdb_id = $1
+	           LIMIT 1`
 
 	var movie domain.Movie
-	err := r.pool.QueryRow(ctx, query, imdbID).Scan(
-		&movie.ID, &movie.ImdbID, &movie.Title, &movie.Year, &movie.Genre,
-		&movie.Director, &movie.Actors, &movie.Plot, &movie.PosterURL,
-		&movie.ImdbRating, &movie.CreatedAt,
-	)
+	err := r.pool.QueryRow(ctx, query, imdbID).Scan(movieFields(&movie)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.ErrNotFound
@@ -71,9 +108,35 @@ func (r *MovieRepo) GetByImdbID(ctx context.Context, imdbID string) (*domain.Mov
 	return &movie, nil
 }
 
+// GetByImdbIDs returns the stored movies among imdbIDs, in no particular order.
+func (r *MovieRepo) GetByImdbIDs(ctx context.Context, imdbIDs []string) ([]domain.Movie, error) {
+	query := `SELECT ` + movieColumns + `
+	           FROM movies m WHERE m.imdb_id = ANY($1)`
+
+	rows, err := r.pool.Query(ctx, query, imdbIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movies []domain.Movie
+	for rows.Next() {
+		var m domain.Movie
+		if err := rows.Scan(movieFields(&m)...); err != nil {
+			return nil, err
+		}
+		movies = append(movies, m)
+	}
+	return movies, rows.Err()
+}
+
 func (r *MovieRepo) GetByGenre(ctx context.Context, genre string, limit int) ([]domain.Movie, error) {
-	query := `SELECT id, imdb_id, title, year, genre, director, actors, plot, poster_url, imdb_rating, created_at
-	           FROM movies WHERE genre ILIKE '%' || $1 || '%' LIMIT $2`
+	query := `SELECT ` + movieColumns + `
+	           FROM movies m
+	           JOIN movie_genres mg ON mg.movie_id = m.id
+	           JOIN genres g ON g.id = mg.genre_id
+	           WHERE lower(g.name) = lower($1) AND NOT m.hidden
+	           LIMIT $2`
 
 	rows, err := r.pool.Query(ctx, query, genre, limit)
 	if err != nil {
@@ -84,14 +147,741 @@ func (r *MovieRepo) GetByGenre(ctx context.Context, genre string, limit int) ([]
 	var movies []domain.Movie
 	for rows.Next() {
 		var m domain.Movie
-		if err := rows.Scan(
-			&m.ID, &m.ImdbID, &m.Title, &m.Year, &m.Genre,
-			&m.Director, &m.Actors, &m.Plot, &m.PosterURL,
-			&m.ImdbRating, &m.CreatedAt,
+		if err := rows.Scan(movieFields(&m)...); err != nil {
+			return nil, err
+		}
+		movies = append(movies, m)
+	}
+	return movies, rows.Err()
+}
+
+// SaveCredits replaces a movie's genre and people links, creating any genre
+// or person rows that do not exist yet.
+func (r *MovieRepo) SaveCredits(ctx context.Context, movieID uuid.UUID, genres []string, credits []domain.Credit) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := saveCredits(ctx, tx, movieID, genres, credits); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func saveCredits(ctx context.Context, tx pgx.Tx, movieID uuid.UUID, genres []string, credits []domain.Credit) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM movie_genres WHERE movie_id = $1`, movieID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM movie_credits WHERE movie_id = $1`, movieID); err != nil {
+		return err
+	}
+
+	if len(genres) > 0 {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO genres (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`,
+			genres,
+		); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO movie_genres (movie_id, genre_id)
+			SELECT $1, id FROM genres WHERE name = ANY($2)
+			ON CONFLICT DO NOTHING`,
+			movieID, genres,
+		); err != nil {
+			return err
+		}
+	}
+
+	if len(credits) > 0 {
+		names := make([]string, len(credits))
+		roles := make([]string, len(credits))
+		orders := make([]int32, len(credits))
+		for i, c := range credits {
+			names[i] = c.Name
+			roles[i] = string(c.Role)
+			orders[i] = int32(c.BillingOrder)
+		}
+
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO people (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`,
+			names,
+		); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO movie_credits (movie_id, person_id, role, billing_order)
+			SELECT $1, p.id, c.role, c.billing_order
+			FROM unnest($2::text[], $3::text[], $4::int[]) AS c(name, role, billing_order)
+			JOIN people p ON p.name = c.name
+			ON CONFLICT DO NOTHING`,
+			movieID, names, roles, orders,
 		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search runs a ranked full-text search over title, plot, director and actors,
+// falling back to trigram similarity on the title so typos still match. It
+// returns one page of movies and the total number of matches.
+func (r *MovieRepo) Search(ctx context.Context, query string, limit, offset int) ([]domain.Movie, int, error) {
+	sql := `
+		WITH q AS (SELECT websearch_to_tsquery('english', $1) AS tsq)
+		SELECT ` + movieColumns + `,
+		       COUNT(*) OVER () AS total
+		FROM movies m, q
+		WHERE (m.search_vector @@ q.tsq OR m.title % $1) AND NOT m.hidden
+		ORDER BY ts_rank_cd(m.search_vector, q.tsq) + similarity(m.title, $1) DESC, m.title
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.pool.Query(ctx, sql, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var movies []domain.Movie
+	total := 0
+	for rows.Next() {
+		var m domain.Movie
+		if err := rows.Scan(append(movieFields(&m), &total)...); err != nil {
+			return nil, 0, err
+		}
+		movies = append(movies, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// Past the last page the window count has no row to ride on.
+	if len(movies) == 0 && offset > 0 {
+		err := r.pool.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM movies m, websearch_to_tsquery('english', $1) AS tsq
+			WHERE (m.search_vector @@ tsq OR m.title % $1) AND NOT m.hidden`,
+			query,
+		).Scan(&total)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return movies, total, nil
+}
+
+// movieSortKeys maps each browse ordering to its sort expression, the SQL
+// type its cursor value is cast to, and whether it sorts descending. Nullable
+// columns are coalesced so every row has a comparable key;
+// domain.CatalogMovie.SortValue must produce the same values.
+var movieSortKeys = map[domain.MovieSort]struct {
+	expr, cast string
+	desc       bool
+}{
+	domain.SortTitle:      {"m.title", "text", false},
+	domain.SortYear:       {"COALESCE(m.release_year, 0)", "integer", true},
+	domain.SortImdbRating: {"COALESCE(m.imdb_score, -1)", "numeric", true},
+	domain.SortPopularity: {"(COALESCE(r.cnt, 0) + COALESCE(w.cnt, 0))", "bigint", true},
+	domain.SortRecent:     {"m.created_at", "timestamptz", true},
+}
+
+// List returns one keyset page of stored movies matching the filter.
+func (r *MovieRepo) List(ctx context.Context, q domain.MovieListQuery) ([]domain.CatalogMovie, error) {
+	key, ok := movieSortKeys[q.Filter.Sort]
+	if !ok {
+		key = movieSortKeys[domain.SortRecent]
+	}
+
+	where := []string{"NOT m.hidden"}
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	f := q.Filter
+	if f.Genre != "" {
+		where = append(where, `EXISTS (SELECT 1 FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id
+		                               WHERE mg.movie_id = m.id AND lower(g.name) = lower(`+arg(f.Genre)+`))`)
+	}
+	if f.Director != "" {
+		where = append(where, `EXISTS (SELECT 1 FROM movie_credits mc JOIN people p ON p.id = mc.person_id
+		                               WHERE mc.movie_id = m.id AND mc.role = 'director'
+		                                 AND lower(p.name) = lower(`+arg(f.Director)+`))`)
+	}
+	if f.YearFrom != 0 {
+		where = append(where, "m.release_year >= "+arg(f.YearFrom))
+	}
+	if f.YearTo != 0 {
+		where = append(where, "m.release_year <= "+arg(f.YearTo))
+	}
+	if f.MinImdbRating != 0 {
+		where = append(where, "m.imdb_score >= "+arg(f.MinImdbRating))
+	}
+	if f.MinCommunityRating != 0 {
+		where = append(where, "r.avg_score >= "+arg(f.MinCommunityRating))
+	}
+	if q.After != nil {
+		op := ">"
+		if key.desc {
+			op = "<"
+		}
+		where = append(where, fmt.Sprintf("(%s, m.id) %s (%s::%s, %s::uuid)",
+			key.expr, op, arg(q.After.Value), key.cast, arg(q.After.ID)))
+	}
+
+	dir := "ASC"
+	if key.desc {
+		dir = "DESC"
+	}
+	orderBy := fmt.Sprintf(" ORDER BY %s %s, m.id %s", key.expr, dir, dir)
+
+	var sql string
+	if q.Filter.Sort == domain.SortPopularity || f.MinCommunityRating != 0 {
+		// The aggregates decide which rows make the page, so they are
+		// computed for every candidate.
+		sql = `
+		SELECT ` + movieColumns + `,
+		       r.avg_score, COALESCE(r.cnt, 0), COALESCE(w.cnt, 0)
+		FROM movies m
+		LEFT JOIN (SELECT movie_id, ROUND(AVG(score), 2)::float8 AS avg_score, COUNT(*) AS cnt
+		           FROM ratings GROUP BY movie_id) r ON r.movie_id = m.id
+		LEFT JOIN (SELECT movie_id, COUNT(DISTINCT user_id) AS cnt
+		           FROM watchlists GROUP BY movie_id) w ON w.movie_id = m.id
+		WHERE ` + strings.Join(where, " AND ") + orderBy + " LIMIT " + arg(q.Limit)
+	} else {
+		// Otherwise pick the page first and aggregate only its rows.
+		sql = `
+		SELECT ` + movieColumns + `,
+		       r.avg_score, r.cnt, w.cnt
+		FROM (SELECT m.* FROM movies m
+		      WHERE ` + strings.Join(where, " AND ") + orderBy + " LIMIT " + arg(q.Limit) + `) m
+		LEFT JOIN LATERAL (SELECT ROUND(AVG(score), 2)::float8 AS avg_score, COUNT(*) AS cnt
+		                   FROM ratings WHERE movie_id = m.id) r ON true
+		LEFT JOIN LATERAL (SELECT COUNT(DISTINCT user_id) AS cnt
+		                   FROM watchlists WHERE movie_id = m.id) w ON true` + orderBy
+	}
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movies []domain.CatalogMovie
+	for rows.Next() {
+		var m domain.CatalogMovie
+		if err := rows.Scan(append(movieFields(&m.Movie),
+			&m.CommunityRating, &m.RatingCount, &m.WatchlistCount)...); err != nil {
 			return nil, err
 		}
 		movies = append(movies, m)
 	}
 	return movies, rows.Err()
 }
+
+// SimilarCandidates returns the source movie's overlap counts and up to limit
+// movies sharing a genre, director, actor or liker (a user who rated both 7
+// or higher) with it, ordered by a rough overlap count.
+func (r *MovieRepo) SimilarCandidates(ctx context.Context, movieID uuid.UUID, limit int) (*domain.SimilaritySource, []domain.SimilarityCandidate, error) {
+	var src domain.SimilaritySource
+	err := r.pool.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM movie_genres WHERE movie_id = $1),
+		       (SELECT COUNT(*) FROM movie_credits WHERE movie_id = $1 AND role = 'director'),
+		       (SELECT COUNT(*) FROM movie_credits WHERE movie_id = $1 AND role = 'actor'),
+		       (SELECT COUNT(*) FROM ratings WHERE movie_id = $1 AND score >= 7)`,
+		movieID,
+	).Scan(&src.GenreCount, &src.DirectorCount, &src.ActorCount, &src.LikeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := `
+		WITH genre_match AS (
+			SELECT mg.movie_id, array_agg(g.name ORDER BY g.name) AS names
+			FROM movie_genres mg
+			JOIN movie_genres src ON src.genre_id = mg.genre_id AND src.movie_id = $1
+			JOIN genres g ON g.id = mg.genre_id
+			WHERE mg.movie_id <> $1
+			GROUP BY mg.movie_id
+		), credit_match AS (
+			SELECT mc.movie_id,
+			       array_agg(p.name ORDER BY p.name) FILTER (WHERE mc.role = 'director') AS directors,
+			       array_agg(p.name ORDER BY mc.billing_order) FILTER (WHERE mc.role = 'actor') AS actors
+			FROM movie_credits mc
+			JOIN movie_credits src ON src.person_id = mc.person_id AND src.role = mc.role AND src.movie_id = $1
+			JOIN people p ON p.id = mc.person_id
+			WHERE mc.movie_id <> $1 AND mc.role IN ('director', 'actor')
+			GROUP BY mc.movie_id
+		), co_match AS (
+			SELECT r.movie_id, COUNT(*) AS co_likes
+			FROM ratings r
+			JOIN ratings src ON src.user_id = r.user_id AND src.movie_id = $1 AND src.score >= 7
+			WHERE r.movie_id <> $1 AND r.score >= 7
+			GROUP BY r.movie_id
+		), candidates AS (
+			SELECT movie_id FROM genre_match
+			UNION SELECT movie_id FROM credit_match
+			UNION SELECT movie_id FROM co_match
+		)
+		SELECT ` + movieColumns + `,
+		       COALESCE(gm.names, '{}'),
+		       (SELECT COUNT(*) FROM movie_genres WHERE movie_id = m.id),
+		       COALESCE(cm.directors, '{}'),
+		       COALESCE(cm.actors, '{}'),
+		       COALESCE(co.co_likes, 0),
+		       (SELECT COUNT(*) FROM ratings WHERE movie_id = m.id AND score >= 7)
+		FROM candidates c
+		JOIN movies m ON m.id = c.movie_id
+		LEFT JOIN genre_match gm ON gm.movie_id = m.id
+		LEFT JOIN credit_match cm ON cm.movie_id = m.id
+		LEFT JOIN co_match co ON co.movie_id = m.id
+		WHERE NOT m.hidden
+		ORDER BY COALESCE(cardinality(gm.names), 0)
+		         + 2 * COALESCE(cardinality(cm.directors), 0)
+		         + COALESCE(cardinality(cm.actors), 0)
+		         + COALESCE(co.co_likes, 0) DESC,
+		         m.imdb_score DESC NULLS LAST, m.id
+		LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, movieID, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var candidates []domain.SimilarityCandidate
+	for rows.Next() {
+		var c domain.SimilarityCandidate
+		dest := append(movieFields(&c.Movie),
+			&c.SharedGenres, &c.GenreCount, &c.SharedDirectors, &c.SharedActors, &c.CoLikes, &c.LikeCount)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return &src, candidates, rows.Err()
+}
+
+const updateMovieQuery = `
+		UPDATE movies SET
+			title = $1, year = $2, genre = $3, director = $4, actors = $5, plot = $6,
+			poster_url = $7, imdb_rating = $8, rated = $9, released = $10, runtime_minutes = $11,
+			writer = $12, language = $13, country = $14, awards = $15, type = $16,
+			release_year = $17, imdb_score = $18, total_seasons = $19, updated_at = $20
+		WHERE id = $21`
+
+func updateMovieArgs(movie *domain.Movie) []interface{} {
+	return []interface{}{
+		movie.Title, movie.Year, movie.Genre, movie.Director, movie.Actors, movie.Plot,
+		movie.PosterURL, movie.ImdbRating, movie.Rated, movie.Released, movie.RuntimeMinutes,
+		movie.Writer, movie.Language, movie.Country, movie.Awards, movie.Type,
+		movie.ReleaseYear, movie.ImdbScore, movie.TotalSeasons, movie.UpdatedAt, movie.ID,
+	}
+}
+
+func updateMovie(ctx context.Context, q execer, movie *domain.Movie) error {
+	tag, err := q.Exec(ctx, updateMovieQuery, updateMovieArgs(movie)...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+// SaveRefresh writes refreshed metadata, and optionally its genre and people
+// links, in one transaction, but only if the movie's updated_at still equals
+// seen. It reports false without writing when the movie was edited, locked,
+// hidden or deleted since it was read, so a refresh never overwrites a
+// concurrent admin change.
+func (r *MovieRepo) SaveRefresh(ctx context.Context, edit domain.MovieEdit, seen time.Time) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, updateMovieQuery+` AND updated_at = $22`, append(updateMovieArgs(edit.Movie), seen)...)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	if edit.SaveCredits {
+		if err := saveCredits(ctx, tx, edit.Movie.ID, edit.Genres, edit.Credits); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit(ctx)
+}
+
+// SaveEdit applies an admin edit and writes its audit entry in one
+// transaction, so the edit never lands without its audit row.
+func (r *MovieRepo) SaveEdit(ctx context.Context, edit domain.MovieEdit) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := updateMovie(ctx, tx, edit.Movie); err != nil {
+		return err
+	}
+	if err := setLockedFields(ctx, tx, edit.Movie.ID, edit.Movie.LockedFields); err != nil {
+		return err
+	}
+	if edit.SaveCredits {
+		if err := saveCredits(ctx, tx, edit.Movie.ID, edit.Genres, edit.Credits); err != nil {
+			return err
+		}
+	}
+	if err := insertAudit(ctx, tx, edit.Audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SetHidden hides a movie from search and recommendations, or shows it
+// again, writing the audit entry in the same transaction.
+func (r *MovieRepo) SetHidden(ctx context.Context, id uuid.UUID, hidden bool, audit *domain.MovieAuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE movies SET hidden = $1, updated_at = NOW() WHERE id = $2`, hidden, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	if err := insertAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListHiddenImdbIDs returns which of the given IMDb IDs belong to hidden movies.
+func (r *MovieRepo) ListHiddenImdbIDs(ctx context.Context, imdbIDs []string) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT imdb_id FROM movies WHERE imdb_id = ANY($1) AND hidden`, imdbIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hidden []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		hidden = append(hidden, id)
+	}
+	return hidden, rows.Err()
+}
+
+// SetLockedFields replaces the fields that refreshes must not overwrite,
+// writing the audit entry in the same transaction.
+func (r *MovieRepo) SetLockedFields(ctx context.Context, id uuid.UUID, fields []string, audit *domain.MovieAuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setLockedFields(ctx, tx, id, fields); err != nil {
+		return err
+	}
+	if err := insertAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func setLockedFields(ctx context.Context, q execer, id uuid.UUID, fields []string) error {
+	if fields == nil {
+		fields = []string{}
+	}
+	// Bumping updated_at makes an in-flight refresh notice the new locks.
+	tag, err := q.Exec(ctx, `UPDATE movies SET locked_fields = $1, updated_at = NOW() WHERE id = $2`, fields, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+// Merge folds the duplicate sourceID into targetID in one transaction.
+// Ratings move to the target unless the user already rated it, and watchlist
+// entries unless the target is already on the same list; otherwise the
+// duplicate's row is dropped. For series, episodes the target lacks move to
+// it and episode progress is re-pointed to the target's episode with the same
+// season and number, including progress of dropped watchlist entries, which
+// goes to the target's entry on the same list. Tags of dropped ratings and
+// watchlist entries are added to the user's surviving rating or entry. The source's watch history,
+// audit history and aliases move too, and its IMDb ID becomes an alias of the target before the
+// source row is deleted. audit is completed with the merge counts and written
+// in the same transaction.
+func (r *MovieRepo) Merge(ctx context.Context, sourceID, targetID uuid.UUID, audit *domain.MovieAuditEntry) (*domain.MergeResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var result domain.MergeResult
+	steps := []struct {
+		query string
+		count *int
+	}{
+		{`UPDATE episodes e SET series_id = $2
+		  WHERE e.series_id = $1
+		    AND NOT EXISTS (SELECT 1 FROM episodes t
+		                    WHERE t.series_id = $2 AND t.season = e.season AND t.episode = e.episode)`, nil},
+		{`INSERT INTO episode_progress (watchlist_id, episode_id, watched_at)
+		  SELECT p.watchlist_id, t.id, p.watched_at
+		  FROM episode_progress p
+		  JOIN episodes s ON s.id = p.episode_id AND s.series_id = $1
+		  JOIN episodes t ON t.series_id = $2 AND t.season = s.season AND t.episode = s.episode
+		  ON CONFLICT (watchlist_id, episode_id) DO NOTHING`, nil},
+		{`UPDATE ratings r SET movie_id = $2
+		  WHERE r.movie_id = $1
+		    AND NOT EXISTS (SELECT 1 FROM ratings t WHERE t.movie_id = $2 AND t.user_id = r.user_id)`, &result.RatingsMoved},
+		{`INSERT INTO rating_tags (rating_id, tag_id)
+		  SELECT t.id, rt.tag_id
+		  FROM rating_tags rt
+		  JOIN ratings r ON r.id = rt.rating_id AND r.movie_id = $1
+		  JOIN ratings t ON t.movie_id = $2 AND t.user_id = r.user_id
+		  ON CONFLICT (rating_id, tag_id) DO NOTHING`, &result.TagsMerged},
+		{`DELETE FROM ratings WHERE movie_id = $1`, &result.RatingsDropped},
+		{`UPDATE watchlists w SET movie_id = $2
+		  WHERE w.movie_id = $1
+		    AND NOT EXISTS (SELECT 1 FROM watchlists t WHERE t.movie_id = $2 AND t.list_id = w.list_id)`, &result.WatchlistsMoved},
+		{`INSERT INTO episode_progress (watchlist_id, episode_id, watched_at)
+		  SELECT t.id, p.episode_id, p.watched_at
+		  FROM episode_progress p
+		  JOIN watchlists w ON w.id = p.watchlist_id AND w.movie_id = $1
+		  JOIN watchlists t ON t.list_id = w.list_id AND t.movie_id = $2
+		  JOIN episodes e ON e.id = p.episode_id AND e.series_id = $2
+		  ON CONFLICT (watchlist_id, episode_id) DO NOTHING`, &result.EpisodeProgressMerged},
+		{`INSERT INTO watchlist_tags (watchlist_id, tag_id)
+		  SELECT t.id, wt.tag_id
+		  FROM watchlist_tags wt
+		  JOIN watchlists w ON w.id = wt.watchlist_id AND w.movie_id = $1
+		  JOIN watchlists t ON t.list_id = w.list_id AND t.movie_id = $2
+		  ON CONFLICT (watchlist_id, tag_id) DO NOTHING`, &result.TagsMerged},
+		{`DELETE FROM watchlists WHERE movie_id = $1`, &result.WatchlistsDropped},
+		{`UPDATE watch_events SET movie_id = $2 WHERE movie_id = $1`, nil},
+		{`UPDATE movie_aliases SET movie_id = $2 WHERE movie_id = $1`, nil},
+		{`UPDATE movie_audit_log SET movie_id = $2 WHERE movie_id = $1`, nil},
+	}
+	for _, step := range steps {
+		tag, err := tx.Exec(ctx, step.query, sourceID, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if step.count != nil {
+			*step.count += int(tag.RowsAffected())
+		}
+	}
+
+	err = tx.QueryRow(ctx, `DELETE FROM movies WHERE id = $1 RETURNING imdb_id`, sourceID).Scan(&result.MergedImdbID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO movie_aliases (imdb_id, movie_id) VALUES ($1, $2)
+		ON CONFLICT (imdb_id) DO UPDATE SET movie_id = EXCLUDED.movie_id`,
+		result.MergedImdbID, targetID,
+	); err != nil {
+		return nil, err
+	}
+
+	audit.Changes = result.AuditChanges()
+	if err := insertAudit(ctx, tx, audit); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MarkRefreshed records that a movie was checked against the provider.
+func (r *MovieRepo) MarkRefreshed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE movies SET refreshed_at = $1, refresh_attempted_at = $1 WHERE id = $2`, at, id)
+	return err
+}
+
+// MarkRefreshAttempted records a refresh attempt that failed, leaving the
+// movie stale but moving it behind every movie not yet attempted.
+func (r *MovieRepo) MarkRefreshAttempted(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.pool.Exec(ctx, `UPDATE movies SET refresh_attempted_at = $1 WHERE id = $2`, at, id)
+	return err
+}
+
+// ListStale returns up to limit movies last refreshed before olderThan, least
+// recently attempted first, so titles that keep failing rotate to the back.
+func (r *MovieRepo) ListStale(ctx context.Context, olderThan time.Time, limit int) ([]domain.Movie, error) {
+	query := `SELECT ` + movieColumns + `
+	           FROM movies m
+	           WHERE m.refreshed_at < $1
+	           ORDER BY COALESCE(m.refresh_attempted_at, m.refreshed_at)
+	           LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, olderThan, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movies []domain.Movie
+	for rows.Next() {
+		var m domain.Movie
+		if err := rows.Scan(movieFields(&m)...); err != nil {
+			return nil, err
+		}
+		movies = append(movies, m)
+	}
+	return movies, rows.Err()
+}
+
+// ListTitles returns up to limit movies ordered by IMDb ID, starting after
+// afterImdbID, for rebuilding the autocomplete index. Rank is the IMDb score.
+func (r *MovieRepo) ListTitles(ctx context.Context, afterImdbID string, limit int) ([]domain.TitleSuggestion, error) {
+	query := `SELECT imdb_id, title, COALESCE(year, ''), COALESCE(type, ''), COALESCE(imdb_score, 0)::float8
+	           FROM movies
+	           WHERE imdb_id > $1 AND NOT hidden
+	           ORDER BY imdb_id
+	           LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, afterImdbID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var titles []domain.TitleSuggestion
+	for rows.Next() {
+		var t domain.TitleSuggestion
+		if err := rows.Scan(&t.ImdbID, &t.Title, &t.Year, &t.Type, &t.Rank); err != nil {
+			return nil, err
+		}
+		titles = append(titles, t)
+	}
+	return titles, rows.Err()
+}
+
+// ImportBatch bulk-loads movies with their genres and credits using COPY into
+// temporary staging tables. Movies already in the catalog are left untouched,
+// IDs merged into another movie are not recreated, and only newly inserted
+// movies get genre and credit links. It returns the
+// number of movies inserted.
+func (r *MovieRepo) ImportBatch(ctx context.Context, batch []domain.MovieImport) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		CREATE TEMP TABLE movie_import (
+			id UUID, imdb_id TEXT, title TEXT, year TEXT, genre TEXT, director TEXT, actors TEXT,
+			writer TEXT, imdb_rating TEXT, type TEXT, release_year INTEGER, runtime_minutes INTEGER,
+			imdb_score NUMERIC(3,1), created_at TIMESTAMPTZ, refreshed_at TIMESTAMPTZ
+		) ON COMMIT DROP;
+		CREATE TEMP TABLE genre_import (imdb_id TEXT, name TEXT) ON COMMIT DROP;
+		CREATE TEMP TABLE credit_import (imdb_id TEXT, name TEXT, role TEXT, billing_order INTEGER) ON COMMIT DROP`,
+	); err != nil {
+		return 0, err
+	}
+
+	var movieRows, genreRows, creditRows [][]interface{}
+	for _, item := range batch {
+		m := item.Movie
+		movieRows = append(movieRows, []interface{}{
+			m.ID, m.ImdbID, m.Title, m.Year, m.Genre, m.Director, m.Actors,
+			m.Writer, m.ImdbRating, m.Type, m.ReleaseYear, m.RuntimeMinutes,
+			m.ImdbScore, m.CreatedAt, m.RefreshedAt,
+		})
+		for _, g := range item.Genres {
+			genreRows = append(genreRows, []interface{}{m.ImdbID, g})
+		}
+		for _, c := range item.Credits {
+			creditRows = append(creditRows, []interface{}{m.ImdbID, c.Name, string(c.Role), c.BillingOrder})
+		}
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"movie_import"}, []string{
+		"id", "imdb_id", "title", "year", "genre", "director", "actors",
+		"writer", "imdb_rating", "type", "release_year", "runtime_minutes",
+		"imdb_score", "created_at", "refreshed_at",
+	}, pgx.CopyFromRows(movieRows)); err != nil {
+		return 0, err
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"genre_import"},
+		[]string{"imdb_id", "name"}, pgx.CopyFromRows(genreRows)); err != nil {
+		return 0, err
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"credit_import"},
+		[]string{"imdb_id", "name", "role", "billing_order"}, pgx.CopyFromRows(creditRows)); err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO movies (id, imdb_id, title, year, genre, director, actors, plot, poster_url,
+		                    imdb_rating, writer, type, release_year, runtime_minutes, imdb_score,
+		                    created_at, updated_at, refreshed_at)
+		SELECT id, imdb_id, title, year, genre, director, actors, '', '',
+		       imdb_rating, writer, type, release_year, runtime_minutes, imdb_score,
+		       created_at, created_at, refreshed_at
+		FROM movie_import t
+		WHERE NOT EXISTS (SELECT 1 FROM movie_aliases a WHERE a.imdb_id = t.imdb_id)
+		ON CONFLICT (imdb_id) DO NOTHING`)
+	if err != nil {
+		return 0, err
+	}
+
+	// Staged IDs only match rows that were just inserted, so existing movies
+	// keep their provider-sourced genres and credits.
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO genres (name) SELECT DISTINCT name FROM genre_import ON CONFLICT (name) DO NOTHING;
+		INSERT INTO movie_genres (movie_id, genre_id)
+		SELECT m.id, g.id
+		FROM genre_import gi
+		JOIN movie_import mi ON mi.imdb_id = gi.imdb_id
+		JOIN movies m ON m.id = mi.id
+		JOIN genres g ON g.name = gi.name
+		ON CONFLICT DO NOTHING;
+		INSERT INTO people (name) SELECT DISTINCT name FROM credit_import ON CONFLICT (name) DO NOTHING;
+		INSERT INTO movie_credits (movie_id, person_id, role, billing_order)
+		SELECT m.id, p.id, ci.role, ci.billing_order
+		FROM credit_import ci
+		JOIN movie_import mi ON mi.imdb_id = ci.imdb_id
+		JOIN movies m ON m.id = mi.id
+		JOIN people p ON p.name = ci.name
+		ON CONFLICT DO NOTHING`,
+	); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
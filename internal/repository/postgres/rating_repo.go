@@ -11,6 +11,16 @@ import (
 	appErr "github.com/namru/movie-recommend/internal/errors"
 )
 
+// ratingColumns selects a ratings row aliased r with its tag names.
+const ratingColumns = `r.id, r.user_id, r.movie_id, r.score, r.review, r.created_at, r.updated_at,
+		       ARRAY(SELECT t.name FROM rating_tags rt JOIN user_tags t ON t.id = rt.tag_id
+		             WHERE rt.rating_id = r.id ORDER BY t.name)`
+
+// ratingFields returns scan destinations matching ratingColumns.
+func ratingFields(rt *domain.Rating) []interface{} {
+	return []interface{}{&rt.ID, &rt.UserID, &rt.MovieID, &rt.Score, &rt.Review, &rt.CreatedAt, &rt.UpdatedAt, &rt.Tags}
+}
+
 type RatingRepo struct {
 	pool *pgxpool.Pool
 }
@@ -39,20 +49,15 @@ func (r *RatingRepo) Create(ctx context.Context, rating *domain.Rating) error {
 
 func (r *RatingRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Rating, error) {
 	query := `
-		SELECT r.id, r.user_id, r.movie_id, r.score, r.review, r.created_at, r.updated_at,
-		       m.id, m.imdb_id, m.title, m.year, m.genre, m.director, m.actors, m.plot, m.poster_url, m.imdb_rating, m.created_at
+		SELECT ` + ratingColumns + `,
+		       ` + movieColumns + `
 		FROM ratings r
 		JOIN movies m ON m.id = r.movie_id
 		WHERE r.id = $1`
 
 	var rt domain.Rating
 	var m domain.Movie
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&rt.ID, &rt.UserID, &rt.MovieID, &rt.Score, &rt.Review,
-		&rt.CreatedAt, &rt.UpdatedAt,
-		&m.ID, &m.ImdbID, &m.Title, &m.Year, &m.Genre, &m.Director,
-		&m.Actors, &m.Plot, &m.PosterURL, &m.ImdbRating, &m.CreatedAt,
-	)
+	err := r.pool.QueryRow(ctx, query, id).Scan(append(ratingFields(&rt), movieFields(&m)...)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.ErrNotFound
@@ -63,16 +68,22 @@ func (r *RatingRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Rating,
 	return &rt, nil
 }
 
-func (r *RatingRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Rating, error) {
+// GetByUserID returns the user's ratings, newest first. When tags are given,
+// only ratings carrying every one of them are returned.
+func (r *RatingRepo) GetByUserID(ctx context.Context, userID uuid.UUID, tags []string) ([]domain.Rating, error) {
 	query := `
-		SELECT r.id, r.user_id, r.movie_id, r.score, r.review, r.created_at, r.updated_at,
-		       m.id, m.imdb_id, m.title, m.year, m.genre, m.director, m.actors, m.plot, m.poster_url, m.imdb_rating, m.created_at
+		SELECT ` + ratingColumns + `,
+		       ` + movieColumns + `
 		FROM ratings r
 		JOIN movies m ON m.id = r.movie_id
 		WHERE r.user_id = $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM unnest($2::text[]) AS want(name)
+		      WHERE NOT EXISTS (SELECT 1 FROM rating_tags rt JOIN user_tags t ON t.id = rt.tag_id
+		                        WHERE rt.rating_id = r.id AND t.name = want.name))
 		ORDER BY r.created_at DESC`
 
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := r.pool.Query(ctx, query, userID, tags)
 	if err != nil {
 		return nil, err
 	}
@@ -82,12 +93,7 @@ func (r *RatingRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domai
 	for rows.Next() {
 		var rt domain.Rating
 		var m domain.Movie
-		if err := rows.Scan(
-			&rt.ID, &rt.UserID, &rt.MovieID, &rt.Score, &rt.Review,
-			&rt.CreatedAt, &rt.UpdatedAt,
-			&m.ID, &m.ImdbID, &m.Title, &m.Year, &m.Genre, &m.Director,
-			&m.Actors, &m.Plot, &m.PosterURL, &m.ImdbRating, &m.CreatedAt,
-		); err != nil {
+		if err := rows.Scan(append(ratingFields(&rt), movieFields(&m)...)...); err != nil {
 			return nil, err
 		}
 		rt.Movie = &m
@@ -123,12 +129,12 @@ func (r *RatingRepo) Delete(ctx context.Context, id uuid.UUID) error {
 // GetTopGenresByUser returns the most common genres from movies the user rated highly.
 func (r *RatingRepo) GetTopGenresByUser(ctx context.Context, userID uuid.UUID, minScore int, limit int) ([]string, error) {
 	query := `
-		SELECT DISTINCT TRIM(g) as genre
+		SELECT g.name
 		FROM ratings r
-		JOIN movies m ON m.id = r.movie_id,
-		LATERAL unnest(string_to_array(m.genre, ',')) AS g
+		JOIN movie_genres mg ON mg.movie_id = r.movie_id
+		JOIN genres g ON g.id = mg.genre_id
 		WHERE r.user_id = $1 AND r.score >= $2
-		GROUP BY TRIM(g)
+		GROUP BY g.name
 		ORDER BY COUNT(*) DESC
 		LIMIT $3`
 
@@ -169,3 +175,42 @@ func (r *RatingRepo) GetRatedMovieIDs(ctx context.Context, userID uuid.UUID) ([]
 	}
 	return ids, rows.Err()
 }
+
+// GetByUserAndMovie returns the user's rating for a movie (without the movie join).
+func (r *RatingRepo) GetByUserAndMovie(ctx context.Context, userID, movieID uuid.UUID) (*domain.Rating, error) {
+	query := `
+		SELECT ` + ratingColumns + `
+		FROM ratings r
+		WHERE r.user_id = $1 AND r.movie_id = $2`
+
+	var rt domain.Rating
+	err := r.pool.QueryRow(ctx, query, userID, movieID).Scan(ratingFields(&rt)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &rt, nil
+}
+
+// GetScoreHistogram returns the number of ratings per score for a movie.
+func (r *RatingRepo) GetScoreHistogram(ctx context.Context, movieID uuid.UUID) (map[int]int, error) {
+	query := `SELECT score, COUNT(*) FROM ratings WHERE movie_id = $1 GROUP BY score`
+
+	rows, err := r.pool.Query(ctx, query, movieID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histogram := make(map[int]int)
+	for rows.Next() {
+		var score, count int
+		if err := rows.Scan(&score, &count); err != nil {
+			return nil, err
+		}
+		histogram[score] = count
+	}
+	return histogram, rows.Err()
+}
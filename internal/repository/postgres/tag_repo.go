@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+)
+
+// tagColumns selects a user_tags row aliased t with its usage counts.
+const tagColumns = `t.id, t.user_id, t.name, t.created_at,
+		       (SELECT COUNT(*) FROM watchlist_tags wt WHERE wt.tag_id = t.id),
+		       (SELECT COUNT(*) FROM rating_tags rt WHERE rt.tag_id = t.id)`
+
+func tagFields(t *domain.Tag) []interface{} {
+	return []interface{}{&t.ID, &t.UserID, &t.Name, &t.CreatedAt, &t.WatchlistCount, &t.RatingCount}
+}
+
+// tagLinks lists the tables linking tags to tagged rows, with the column
+// naming the tagged row.
+var tagLinks = []struct{ table, column string }{
+	{"watchlist_tags", "watchlist_id"},
+	{"rating_tags", "rating_id"},
+}
+
+type TagRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewTagRepo(pool *pgxpool.Pool) *TagRepo {
+	return &TagRepo{pool: pool}
+}
+
+func (r *TagRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM user_tags t WHERE t.id = $1`
+
+	var t domain.Tag
+	if err := r.pool.QueryRow(ctx, query, id).Scan(tagFields(&t)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ListByUser returns the user's tags by name, including unused ones.
+func (r *TagRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM user_tags t WHERE t.user_id = $1 ORDER BY t.name`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []domain.Tag
+	for rows.Next() {
+		var t domain.Tag
+		if err := rows.Scan(tagFields(&t)...); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// SetWatchlistTags replaces the tags on a watchlist entry, creating any of
+// the user's tags that do not exist yet.
+func (r *TagRepo) SetWatchlistTags(ctx context.Context, userID, entryID uuid.UUID, names []string) error {
+	return r.setTags(ctx, userID, "watchlist_tags", "watchlist_id", entryID, names)
+}
+
+// SetRatingTags replaces the tags on a rating, creating any of the user's
+// tags that do not exist yet.
+func (r *TagRepo) SetRatingTags(ctx context.Context, userID, ratingID uuid.UUID, names []string) error {
+	return r.setTags(ctx, userID, "rating_tags", "rating_id", ratingID, names)
+}
+
+func (r *TagRepo) setTags(ctx context.Context, userID uuid.UUID, table, column string, id uuid.UUID, names []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO user_tags (user_id, name)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT (user_id, name) DO NOTHING`,
+		userID, names,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE `+column+` = $1`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO `+table+` (`+column+`, tag_id)
+		SELECT $1, id FROM user_tags WHERE user_id = $2 AND name = ANY($3)`,
+		id, userID, names,
+	); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TagRepo) Rename(ctx context.Context, id uuid.UUID, name string) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE user_tags SET name = $1 WHERE id = $2`, name, id)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return appErr.ErrAlreadyExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+// Merge moves every use of sourceID to targetID and deletes sourceID, in one
+// transaction. Rows already carrying both tags keep a single link.
+func (r *TagRepo) Merge(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, link := range tagLinks {
+		query := `
+			INSERT INTO ` + link.table + ` (` + link.column + `, tag_id)
+			SELECT ` + link.column + `, $2 FROM ` + link.table + ` WHERE tag_id = $1
+			ON CONFLICT DO NOTHING`
+		if _, err := tx.Exec(ctx, query, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM user_tags WHERE id = $1`, sourceID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// Delete removes a tag from every entry and rating carrying it.
+func (r *TagRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM user_tags WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
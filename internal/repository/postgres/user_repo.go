@@ -21,11 +21,11 @@ func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
 
 func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, username, email, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO users (id, username, email, password_hash, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
 	_, err := r.pool.Exec(ctx, query,
-		user.ID, user.Username, user.Email, user.PasswordHash,
+		user.ID, user.Username, user.Email, user.PasswordHash, user.Role,
 		user.CreatedAt, user.UpdatedAt,
 	)
 	if err != nil {
@@ -39,11 +39,11 @@ func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
 }
 
 func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	query := `SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT id, username, email, password_hash, role, created_at, updated_at FROM users WHERE id = $1`
 
 	var user domain.User
 	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Role,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
@@ -56,11 +56,11 @@ func (r *UserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, err
 }
 
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	query := `SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT id, username, email, password_hash, role, created_at, updated_at FROM users WHERE email = $1`
 
 	var user domain.User
 	err := r.pool.QueryRow(ctx, query, email).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Role,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
@@ -73,11 +73,11 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*domain.User,
 }
 
 func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
-	query := `SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE username = $1`
+	query := `SELECT id, username, email, password_hash, role, created_at, updated_at FROM users WHERE username = $1`
 
 	var user domain.User
 	err := r.pool.QueryRow(ctx, query, username).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Role,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+)
+
+// watchEventColumns selects a watch_events row aliased e, with its place
+// among the user's viewings of the same movie.
+const watchEventColumns = `e.id, e.user_id, e.movie_id, e.watchlist_id, e.watched_at, e.rating, e.venue,
+		       e.companions, e.created_at,
+		       (SELECT COUNT(*) FROM watch_events p
+		        WHERE p.user_id = e.user_id AND p.movie_id = e.movie_id
+		          AND (p.watched_at, p.id) <= (e.watched_at, e.id))`
+
+// watchEventFields returns scan destinations matching watchEventColumns.
+func watchEventFields(e *domain.WatchEvent) []interface{} {
+	return []interface{}{
+		&e.ID, &e.UserID, &e.MovieID, &e.WatchlistID, &e.WatchedAt, &e.Rating, &e.Venue,
+		&e.Companions, &e.CreatedAt, &e.WatchNumber,
+	}
+}
+
+type WatchEventRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewWatchEventRepo(pool *pgxpool.Pool) *WatchEventRepo {
+	return &WatchEventRepo{pool: pool}
+}
+
+func (r *WatchEventRepo) Create(ctx context.Context, event *domain.WatchEvent) error {
+	query := `
+		INSERT INTO watch_events (id, user_id, movie_id, watchlist_id, watched_at, rating, venue, companions, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.pool.Exec(ctx, query,
+		event.ID, event.UserID, event.MovieID, event.WatchlistID, event.WatchedAt,
+		event.Rating, event.Venue, event.Companions, event.CreatedAt,
+	)
+	return err
+}
+
+func (r *WatchEventRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.WatchEvent, error) {
+	query := `
+		SELECT ` + watchEventColumns + `,
+		       ` + movieColumns + `
+		FROM watch_events e
+		JOIN movies m ON m.id = e.movie_id
+		WHERE e.id = $1`
+
+	var e domain.WatchEvent
+	var m domain.Movie
+	err := r.pool.QueryRow(ctx, query, id).Scan(append(watchEventFields(&e), movieFields(&m)...)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, err
+	}
+	e.Movie = &m
+	return &e, nil
+}
+
+func (r *WatchEventRepo) Update(ctx context.Context, event *domain.WatchEvent) error {
+	query := `
+		UPDATE watch_events
+		SET watched_at = $1, rating = $2, venue = $3, companions = $4
+		WHERE id = $5`
+
+	tag, err := r.pool.Exec(ctx, query,
+		event.WatchedAt, event.Rating, event.Venue, event.Companions, event.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WatchEventRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM watch_events WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+// List returns one keyset page of a user's viewings, newest first.
+func (r *WatchEventRepo) List(ctx context.Context, q domain.WatchHistoryQuery) ([]domain.WatchEvent, error) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	where := []string{"e.user_id = " + arg(q.UserID)}
+
+	if q.ImdbID != "" {
+		where = append(where, "m.imdb_id = "+arg(q.ImdbID))
+	}
+	if !q.From.IsZero() {
+		where = append(where, "e.watched_at >= "+arg(q.From))
+	}
+	if !q.Before.IsZero() {
+		where = append(where, "e.watched_at < "+arg(q.Before))
+	}
+	if q.After != nil {
+		where = append(where, "(e.watched_at, e.id) < ("+arg(q.After.WatchedAt)+", "+arg(q.After.ID)+")")
+	}
+
+	query := `
+		SELECT ` + watchEventColumns + `,
+		       ` + movieColumns + `
+		FROM watch_events e
+		JOIN movies m ON m.id = e.movie_id
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY e.watched_at DESC, e.id DESC
+		LIMIT ` + arg(q.Limit)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.WatchEvent
+	for rows.Next() {
+		var e domain.WatchEvent
+		var m domain.Movie
+		if err := rows.Scan(append(watchEventFields(&e), movieFields(&m)...)...); err != nil {
+			return nil, err
+		}
+		e.Movie = &m
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -3,6 +3,9 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -11,6 +14,37 @@ import (
 	appErr "github.com/namru/movie-recommend/internal/errors"
 )
 
+// watchlistColumns selects a watchlists row aliased w with its tag names.
+const watchlistColumns = `w.id, w.user_id, w.list_id, w.movie_id, w.status, w.priority, w.note, w.position, w.added_at,
+		       ARRAY(SELECT t.name FROM watchlist_tags wt JOIN user_tags t ON t.id = wt.tag_id
+		             WHERE wt.watchlist_id = w.id ORDER BY t.name)`
+
+// watchlistFields returns scan destinations matching watchlistColumns.
+func watchlistFields(w *domain.Watchlist) []interface{} {
+	return []interface{}{&w.ID, &w.UserID, &w.ListID, &w.MovieID, &w.Status, &w.Priority, &w.Note, &w.Position, &w.AddedAt, &w.Tags}
+}
+
+// watchlistSortKeys maps each entry ordering to its sort expressions, the SQL
+// types their cursor values are cast to, and whether they sort descending.
+// All expressions of a key share one direction and are followed by w.id, so a
+// page boundary is a single row comparison; priority negates position to keep
+// the manual order within a priority. domain.Watchlist.SortValues must
+// produce the same values.
+var watchlistSortKeys = map[domain.WatchlistSort]struct {
+	exprs, casts []string
+	desc         bool
+}{
+	domain.WatchlistSortPosition: {[]string{"w.position"}, []string{"float8"}, false},
+	domain.WatchlistSortAddedAt:  {[]string{"w.added_at"}, []string{"timestamptz"}, true},
+	domain.WatchlistSortTitle:    {[]string{"m.title"}, []string{"text"}, false},
+	domain.WatchlistSortYear:     {[]string{"COALESCE(m.release_year, 0)"}, []string{"integer"}, true},
+	domain.WatchlistSortPriority: {[]string{"w.priority", "-w.position"}, []string{"smallint", "float8"}, true},
+}
+
+// minPositionGap is the smallest gap between neighbours that Move will split;
+// below it the list is renumbered first.
+const minPositionGap = 1e-9
+
 type WatchlistRepo struct {
 	pool *pgxpool.Pool
 }
@@ -19,14 +53,19 @@ func NewWatchlistRepo(pool *pgxpool.Pool) *WatchlistRepo {
 	return &WatchlistRepo{pool: pool}
 }
 
+// Create inserts an entry at the top of its list's manual order.
 func (r *WatchlistRepo) Create(ctx context.Context, entry *domain.Watchlist) error {
 	query := `
-		INSERT INTO watchlists (id, user_id, movie_id, status, added_at)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO watchlists (id, user_id, list_id, movie_id, status, priority, note, position, added_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7,
+		        (SELECT COALESCE(MIN(position), 1) - 1 FROM watchlists WHERE list_id = $3),
+		        $8)
+		RETURNING position`
 
-	_, err := r.pool.Exec(ctx, query,
-		entry.ID, entry.UserID, entry.MovieID, entry.Status, entry.AddedAt,
-	)
+	err := r.pool.QueryRow(ctx, query,
+		entry.ID, entry.UserID, entry.ListID, entry.MovieID, entry.Status,
+		entry.Priority, entry.Note, entry.AddedAt,
+	).Scan(&entry.Position)
 	if err != nil {
 		if isDuplicateKeyError(err) {
 			return appErr.ErrAlreadyExists
@@ -38,19 +77,15 @@ func (r *WatchlistRepo) Create(ctx context.Context, entry *domain.Watchlist) err
 
 func (r *WatchlistRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error) {
 	query := `
-		SELECT w.id, w.user_id, w.movie_id, w.status, w.added_at,
-		       m.id, m.imdb_id, m.title, m.year, m.genre, m.director, m.actors, m.plot, m.poster_url, m.imdb_rating, m.created_at
+		SELECT ` + watchlistColumns + `,
+		       ` + movieColumns + `
 		FROM watchlists w
 		JOIN movies m ON m.id = w.movie_id
 		WHERE w.id = $1`
 
 	var w domain.Watchlist
 	var m domain.Movie
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&w.ID, &w.UserID, &w.MovieID, &w.Status, &w.AddedAt,
-		&m.ID, &m.ImdbID, &m.Title, &m.Year, &m.Genre, &m.Director,
-		&m.Actors, &m.Plot, &m.PosterURL, &m.ImdbRating, &m.CreatedAt,
-	)
+	err := r.pool.QueryRow(ctx, query, id).Scan(append(watchlistFields(&w), movieFields(&m)...)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.ErrNotFound
@@ -61,18 +96,83 @@ func (r *WatchlistRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Watc
 	return &w, nil
 }
 
-func (r *WatchlistRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Watchlist, error) {
-	query := `
-		SELECT w.id, w.user_id, w.movie_id, w.status, w.added_at,
-		       m.id, m.imdb_id, m.title, m.year, m.genre, m.director, m.actors, m.plot, m.poster_url, m.imdb_rating, m.created_at
+// List returns one keyset page of a list's entries matching the filter, and
+// the number of matching entries across all pages. It returns
+// appErr.ErrBadRequest if the cursor does not fit the sort.
+func (r *WatchlistRepo) List(ctx context.Context, q domain.WatchlistQuery) ([]domain.Watchlist, int, error) {
+	key, ok := watchlistSortKeys[q.Filter.Sort]
+	if !ok {
+		key = watchlistSortKeys[domain.WatchlistSortPosition]
+	}
+
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	where := []string{"w.list_id = " + arg(q.ListID)}
+
+	f := q.Filter
+	if f.Status != "" {
+		where = append(where, "w.status = "+arg(f.Status))
+	}
+	if f.Genre != "" {
+		where = append(where, `EXISTS (SELECT 1 FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id
+		                               WHERE mg.movie_id = m.id AND lower(g.name) = lower(`+arg(f.Genre)+`))`)
+	}
+	if f.YearFrom != 0 {
+		where = append(where, "m.release_year >= "+arg(f.YearFrom))
+	}
+	if f.YearTo != 0 {
+		where = append(where, "m.release_year <= "+arg(f.YearTo))
+	}
+	if f.Title != "" {
+		where = append(where, "strpos(lower(m.title), lower("+arg(f.Title)+")) > 0")
+	}
+	for _, name := range f.Tags {
+		where = append(where, `EXISTS (SELECT 1 FROM watchlist_tags wt JOIN user_tags t ON t.id = wt.tag_id
+		                               WHERE wt.watchlist_id = w.id AND t.name = `+arg(name)+`)`)
+	}
+	from := `
 		FROM watchlists w
 		JOIN movies m ON m.id = w.movie_id
-		WHERE w.user_id = $1
-		ORDER BY w.added_at DESC`
+		WHERE ` + strings.Join(where, " AND ")
+
+	var total int
+	if err := r.pool.QueryRow(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	dir, op := "ASC", ">"
+	if key.desc {
+		dir, op = "DESC", "<"
+	}
+	if q.After != nil {
+		if len(q.After.Values) != len(key.exprs) {
+			return nil, 0, appErr.ErrBadRequest
+		}
+		values := make([]string, len(key.exprs))
+		for i, v := range q.After.Values {
+			values[i] = arg(v) + "::" + key.casts[i]
+		}
+		from += fmt.Sprintf(" AND (%s, w.id) %s (%s, %s::uuid)",
+			strings.Join(key.exprs, ", "), op, strings.Join(values, ", "), arg(q.After.ID))
+	}
+	orderBy := make([]string, 0, len(key.exprs)+1)
+	for _, e := range append(key.exprs, "w.id") {
+		orderBy = append(orderBy, e+" "+dir)
+	}
+
+	query := `
+		SELECT ` + watchlistColumns + `,
+		       ` + movieColumns +
+		from + `
+		ORDER BY ` + strings.Join(orderBy, ", ") + `
+		LIMIT ` + arg(q.Limit)
 
-	rows, err := r.pool.Query(ctx, query, userID)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
@@ -80,17 +180,32 @@ func (r *WatchlistRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]do
 	for rows.Next() {
 		var w domain.Watchlist
 		var m domain.Movie
-		if err := rows.Scan(
-			&w.ID, &w.UserID, &w.MovieID, &w.Status, &w.AddedAt,
-			&m.ID, &m.ImdbID, &m.Title, &m.Year, &m.Genre, &m.Director,
-			&m.Actors, &m.Plot, &m.PosterURL, &m.ImdbRating, &m.CreatedAt,
-		); err != nil {
-			return nil, err
+		if err := rows.Scan(append(watchlistFields(&w), movieFields(&m)...)...); err != nil {
+			return nil, 0, err
 		}
 		w.Movie = &m
 		list = append(list, w)
 	}
-	return list, rows.Err()
+	return list, total, rows.Err()
+}
+
+// ListMovieIDs returns the IDs of the movies on a list.
+func (r *WatchlistRepo) ListMovieIDs(ctx context.Context, listID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `SELECT movie_id FROM watchlists WHERE list_id = $1`, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
 }
 
 func (r *WatchlistRepo) Update(ctx context.Context, id uuid.UUID, status domain.WatchlistStatus) error {
@@ -105,6 +220,115 @@ func (r *WatchlistRepo) Update(ctx context.Context, id uuid.UUID, status domain.
 	return nil
 }
 
+func (r *WatchlistRepo) UpdateDetails(ctx context.Context, id uuid.UUID, priority domain.WatchlistPriority, note string) error {
+	query := `UPDATE watchlists SET priority = $1, note = $2 WHERE id = $3`
+	tag, err := r.pool.Exec(ctx, query, priority, note, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErr.ErrNotFound
+	}
+	return nil
+}
+
+// Move places an entry directly after afterID in its list's manual order, or
+// at the top when afterID is nil, and returns its new position. Only the moved
+// entry is rewritten unless its neighbours are too close together to split,
+// in which case the whole list is renumbered first.
+func (r *WatchlistRepo) Move(ctx context.Context, listID, entryID uuid.UUID, afterID *uuid.UUID) (float64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize moves within a list so neighbours cannot shift underneath us.
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM user_lists WHERE id = $1 FOR UPDATE`, listID); err != nil {
+		return 0, err
+	}
+
+	position, ok, err := movePosition(ctx, tx, listID, entryID, afterID)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		if err := renumberList(ctx, tx, listID); err != nil {
+			return 0, err
+		}
+		if position, _, err = movePosition(ctx, tx, listID, entryID, afterID); err != nil {
+			return 0, err
+		}
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE watchlists SET position = $1 WHERE id = $2 AND list_id = $3`, position, entryID, listID)
+	if err != nil {
+		return 0, err
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, appErr.ErrNotFound
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// movePosition returns the midpoint between afterID (or the top of the list)
+// and the next entry, ignoring the entry being moved. It reports false when
+// the two are too close to split, including when they share a position.
+func movePosition(ctx context.Context, tx pgx.Tx, listID, entryID uuid.UUID, afterID *uuid.UUID) (float64, bool, error) {
+	var lo *float64
+	if afterID != nil {
+		var p float64
+		err := tx.QueryRow(ctx, `SELECT position FROM watchlists WHERE id = $1 AND list_id = $2`, *afterID, listID).Scan(&p)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return 0, false, appErr.ErrNotFound
+			}
+			return 0, false, err
+		}
+		lo = &p
+	}
+
+	var hi *float64
+	query := `
+		SELECT MIN(position) FROM watchlists
+		WHERE list_id = $1 AND id <> $2 AND id IS DISTINCT FROM $3
+		  AND ($4::float8 IS NULL OR position >= $4)`
+	if err := tx.QueryRow(ctx, query, listID, entryID, afterID, lo).Scan(&hi); err != nil {
+		return 0, false, err
+	}
+
+	switch {
+	case lo == nil && hi == nil:
+		return 0, true, nil
+	case lo == nil:
+		return *hi - 1, true, nil
+	case hi == nil:
+		return *lo + 1, true, nil
+	case *hi-*lo < minPositionGap:
+		return 0, false, nil
+	}
+	return (*lo + *hi) / 2, true, nil
+}
+
+// renumberList rewrites a list's positions as 1..n in their current order.
+func renumberList(ctx context.Context, tx pgx.Tx, listID uuid.UUID) error {
+	query := `
+		UPDATE watchlists w
+		SET position = r.rn
+		FROM (
+			SELECT id, row_number() OVER (ORDER BY position, id) AS rn
+			FROM watchlists
+			WHERE list_id = $1
+		) r
+		WHERE w.id = r.id`
+
+	_, err := tx.Exec(ctx, query, listID)
+	return err
+}
+
 func (r *WatchlistRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM watchlists WHERE id = $1`
 	tag, err := r.pool.Exec(ctx, query, id)
@@ -117,9 +341,54 @@ func (r *WatchlistRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *WatchlistRepo) Exists(ctx context.Context, userID, movieID uuid.UUID) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM watchlists WHERE user_id = $1 AND movie_id = $2)`
+func (r *WatchlistRepo) Exists(ctx context.Context, listID, movieID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM watchlists WHERE list_id = $1 AND movie_id = $2)`
 	var exists bool
-	err := r.pool.QueryRow(ctx, query, userID, movieID).Scan(&exists)
+	err := r.pool.QueryRow(ctx, query, listID, movieID).Scan(&exists)
 	return exists, err
 }
+
+// GetByUserAndMovie returns the user's entry for a movie. When the movie is on
+// several of the user's lists, the default list's entry wins, then the newest.
+func (r *WatchlistRepo) GetByUserAndMovie(ctx context.Context, userID, movieID uuid.UUID) (*domain.Watchlist, error) {
+	query := `
+		SELECT ` + watchlistColumns + `
+		FROM watchlists w
+		JOIN user_lists l ON l.id = w.list_id
+		WHERE w.user_id = $1 AND w.movie_id = $2
+		ORDER BY l.is_default DESC, w.added_at DESC
+		LIMIT 1`
+
+	var w domain.Watchlist
+	err := r.pool.QueryRow(ctx, query, userID, movieID).Scan(watchlistFields(&w)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+// CountByStatus returns how many users have the movie in each watchlist status.
+// A user with the movie on several lists counts once per distinct status.
+func (r *WatchlistRepo) CountByStatus(ctx context.Context, movieID uuid.UUID) (map[domain.WatchlistStatus]int, error) {
+	query := `SELECT status, COUNT(DISTINCT user_id) FROM watchlists WHERE movie_id = $1 GROUP BY status`
+
+	rows, err := r.pool.Query(ctx, query, movieID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.WatchlistStatus]int)
+	for rows.Next() {
+		var status domain.WatchlistStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
@@ -5,8 +5,18 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/namru/movie-recommend/internal/domain"
 )
 
+// releaseLockScript deletes the lock only if the caller still owns it, so an
+// expired lock re-acquired by someone else is never released by mistake.
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
 // CacheRepo implements repository.CacheRepository using Redis.
 type CacheRepo struct {
 	client *redis.Client
@@ -31,3 +41,54 @@ func (r *CacheRepo) Set(ctx context.Context, key string, value string, ttl time.
 func (r *CacheRepo) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
+
+func (r *CacheRepo) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	n, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if n == 1 {
+		if err := r.client.Expire(ctx, key, ttl).Err(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (r *CacheRepo) AcquireLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, token, ttl).Result()
+}
+
+func (r *CacheRepo) ReleaseLock(ctx context.Context, key string, token string) error {
+	return releaseLockScript.Run(ctx, r.client, []string{key}, token).Err()
+}
+
+func (r *CacheRepo) ReplaceSortedSet(ctx context.Context, key string, members []domain.ScoredID, ttl time.Duration) error {
+	zs := make([]redis.Z, len(members))
+	for i, m := range members {
+		zs[i] = redis.Z{Score: m.Score, Member: m.ID}
+	}
+	// MULTI/EXEC so readers never see the set half-written.
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(zs) > 0 {
+			pipe.ZAdd(ctx, key, zs...)
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *CacheRepo) TopSortedSet(ctx context.Context, key string, limit int) ([]domain.ScoredID, error) {
+	zs, err := r.client.ZRevRangeWithScores(ctx, key, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	members := make([]domain.ScoredID, 0, len(zs))
+	for _, z := range zs {
+		id, _ := z.Member.(string)
+		members = append(members, domain.ScoredID{ID: id, Score: z.Score})
+	}
+	return members, nil
+}
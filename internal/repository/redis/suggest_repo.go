@@ -0,0 +1,211 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+const (
+	// suggestLiveKey names the generation readers use; suggestNextKey the
+	// one a running rebuild is filling. Generation "" is the layout used
+	// before rebuilds were generational, so an existing index stays readable.
+	suggestLiveKey = "autocomplete:gen"
+	suggestNextKey = "autocomplete:gen:next"
+
+	// suggestNextTTL expires the marker of a rebuild that died, so live
+	// updates stop writing to its abandoned generation.
+	suggestNextTTL = time.Hour
+
+	// suggestKeepPerPrefix bounds each prefix set; only the best-ranked
+	// titles under a prefix can ever be suggested anyway.
+	suggestKeepPerPrefix = 50
+
+	suggestScanBatch = 500
+)
+
+// SuggestRepo implements repository.SuggestionRepository as one Redis sorted
+// set per prefix, holding IMDb IDs ranked by score, plus a hash of IMDb ID to
+// suggestion JSON. A rebuild fills a fresh generation of these keys and
+// swaps it in, so titles and prefixes that no longer exist do not survive it.
+type SuggestRepo struct {
+	client *redis.Client
+}
+
+func NewSuggestRepo(client *redis.Client) *SuggestRepo {
+	return &SuggestRepo{client: client}
+}
+
+// suggestKeys returns the key namespace of a generation.
+func suggestKeys(gen string) string {
+	if gen == "" {
+		return "autocomplete:"
+	}
+	return "autocomplete:idx:" + gen + ":"
+}
+
+func (r *SuggestRepo) Index(ctx context.Context, entries []domain.SuggestionEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	gens, err := r.writeGenerations(ctx)
+	if err != nil {
+		return err
+	}
+	return r.index(ctx, gens, entries)
+}
+
+func (r *SuggestRepo) Remove(ctx context.Context, imdbID string, prefixes []string) error {
+	gens, err := r.writeGenerations(ctx)
+	if err != nil {
+		return err
+	}
+	pipe := r.client.Pipeline()
+	for _, gen := range gens {
+		ns := suggestKeys(gen)
+		pipe.HDel(ctx, ns+"titles", imdbID)
+		for _, prefix := range prefixes {
+			pipe.ZRem(ctx, ns+"p:"+prefix, imdbID)
+		}
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *SuggestRepo) Suggest(ctx context.Context, prefix string, limit int) ([]domain.TitleSuggestion, error) {
+	gen, err := r.client.Get(ctx, suggestLiveKey).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	ns := suggestKeys(gen)
+
+	ids, err := r.client.ZRevRange(ctx, ns+"p:"+prefix, 0, int64(limit-1)).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	values, err := r.client.HMGet(ctx, ns+"titles", ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+	suggestions := make([]domain.TitleSuggestion, 0, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue // removed between the two reads
+		}
+		var ts domain.TitleSuggestion
+		if err := json.Unmarshal([]byte(s), &ts); err != nil {
+			continue
+		}
+		suggestions = append(suggestions, ts)
+	}
+	return suggestions, nil
+}
+
+// BeginRebuild opens a new, empty generation. Until it is committed, Index
+// and Remove write to it as well as to the live one, so titles stored while
+// the rebuild runs are not lost in the swap.
+func (r *SuggestRepo) BeginRebuild(ctx context.Context) (string, error) {
+	gen := uuid.New().String()
+	if err := r.client.Set(ctx, suggestNextKey, gen, suggestNextTTL).Err(); err != nil {
+		return "", err
+	}
+	return gen, nil
+}
+
+func (r *SuggestRepo) IndexRebuild(ctx context.Context, gen string, entries []domain.SuggestionEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.index(ctx, []string{gen}, entries)
+}
+
+// CommitRebuild makes gen the live generation and then deletes the keys of
+// every other generation, including those of rebuilds that never finished.
+func (r *SuggestRepo) CommitRebuild(ctx context.Context, gen string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, suggestLiveKey, gen, 0)
+		pipe.Del(ctx, suggestNextKey)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	live := suggestKeys(gen)
+	var stale []string
+	flush := func() error {
+		if len(stale) == 0 {
+			return nil
+		}
+		err := r.client.Unlink(ctx, stale...).Err()
+		stale = stale[:0]
+		return err
+	}
+	for _, pattern := range []string{suggestKeys("") + "p:*", suggestKeys("") + "titles", "autocomplete:idx:*"} {
+		iter := r.client.Scan(ctx, 0, pattern, suggestScanBatch).Iterator()
+		for iter.Next(ctx) {
+			if key := iter.Val(); !strings.HasPrefix(key, live) {
+				stale = append(stale, key)
+			}
+			if len(stale) == suggestScanBatch {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
+	return flush()
+}
+
+// writeGenerations returns the live generation and, while a rebuild runs,
+// the one it is filling.
+func (r *SuggestRepo) writeGenerations(ctx context.Context) ([]string, error) {
+	values, err := r.client.MGet(ctx, suggestLiveKey, suggestNextKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	live, _ := values[0].(string)
+	gens := []string{live}
+	if next, ok := values[1].(string); ok && next != live {
+		gens = append(gens, next)
+	}
+	return gens, nil
+}
+
+func (r *SuggestRepo) index(ctx context.Context, gens []string, entries []domain.SuggestionEntry) error {
+	pipe := r.client.Pipeline()
+	touched := make(map[string]bool)
+	for _, e := range entries {
+		data, err := json.Marshal(e.Suggestion)
+		if err != nil {
+			return err
+		}
+		for _, gen := range gens {
+			ns := suggestKeys(gen)
+			pipe.HSet(ctx, ns+"titles", e.Suggestion.ImdbID, data)
+			for prefix, rank := range e.Prefixes {
+				key := ns + "p:" + prefix
+				// Plain ZADD: the entry already holds the title's best rank
+				// per prefix, and a re-indexed title must be able to drop.
+				pipe.ZAdd(ctx, key, redis.Z{Score: rank, Member: e.Suggestion.ImdbID})
+				touched[key] = true
+			}
+		}
+	}
+	for key := range touched {
+		pipe.ZRemRangeByRank(ctx, key, 0, -suggestKeepPerPrefix-1)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
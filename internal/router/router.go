@@ -19,6 +19,14 @@ func Setup(
 	watchlistHandler *handler.WatchlistHandler,
 	ratingHandler *handler.RatingHandler,
 	recHandler *handler.RecommendationHandler,
+	adminHandler *handler.AdminHandler,
+	seriesHandler *handler.SeriesHandler,
+	posterHandler *handler.PosterHandler,
+	trendingHandler *handler.TrendingHandler,
+	curationHandler *handler.CurationHandler,
+	listHandler *handler.ListHandler,
+	tagHandler *handler.TagHandler,
+	historyHandler *handler.HistoryHandler,
 ) *gin.Engine {
 	r := gin.New()
 
@@ -34,6 +42,10 @@ func Setup(
 	// Health check (no auth)
 	r.GET("/api/v1/health", middleware.HealthCheck)
 
+	// Posters (no auth, so they can be used directly in <img> tags; only
+	// movies already in the catalog are served)
+	r.GET("/api/v1/movies/:imdbID/poster", posterHandler.Get)
+
 	// Auth routes (no auth required)
 	auth := r.Group("/api/v1/auth")
 	{
@@ -46,24 +58,76 @@ func Setup(
 	protected.Use(middleware.AuthMiddleware(jwtSecret))
 	{
 		// Movies
+		protected.GET("/movies", movieHandler.List)
 		protected.GET("/movies/search", movieHandler.Search)
+		protected.GET("/movies/autocomplete", movieHandler.Autocomplete)
+		protected.POST("/movies/batch", movieHandler.GetBatch)
+		protected.GET("/movies/trending", trendingHandler.Trending)
+		protected.GET("/movies/popular", trendingHandler.Popular)
 		protected.GET("/movies/:imdbID", movieHandler.GetByImdbID)
+		protected.GET("/movies/:imdbID/seasons/:season", seriesHandler.GetSeason)
+		protected.GET("/movies/:imdbID/similar", recHandler.GetSimilar)
+		protected.GET("/catalog/search", movieHandler.SearchCatalog)
 
-		// Watchlist
+		// Watchlist (the default list)
 		protected.GET("/watchlist", watchlistHandler.GetAll)
 		protected.POST("/watchlist", watchlistHandler.Add)
 		protected.PATCH("/watchlist/:id", watchlistHandler.UpdateStatus)
 		protected.DELETE("/watchlist/:id", watchlistHandler.Remove)
+		protected.POST("/watchlist/:id/move", watchlistHandler.Move)
+		protected.PUT("/watchlist/:id/tags", tagHandler.SetWatchlistTags)
+		protected.GET("/watchlist/:id/progress", seriesHandler.GetProgress)
+		protected.PUT("/watchlist/:id/episodes/:code", seriesHandler.MarkWatched)
+		protected.DELETE("/watchlist/:id/episodes/:code", seriesHandler.UnmarkWatched)
+
+		// Named lists
+		protected.GET("/watchlists", listHandler.GetAll)
+		protected.POST("/watchlists", listHandler.Create)
+		protected.PUT("/watchlists/order", listHandler.Reorder)
+		protected.PATCH("/watchlists/:listID", listHandler.Rename)
+		protected.DELETE("/watchlists/:listID", listHandler.Delete)
+		protected.GET("/watchlists/:listID/entries", watchlistHandler.GetList)
+		protected.POST("/watchlists/:listID/entries", watchlistHandler.AddToList)
 
 		// Ratings
 		protected.POST("/ratings", ratingHandler.Create)
 		protected.GET("/ratings", ratingHandler.GetAll)
 		protected.PUT("/ratings/:id", ratingHandler.Update)
 		protected.DELETE("/ratings/:id", ratingHandler.Delete)
+		protected.PUT("/ratings/:id/tags", tagHandler.SetRatingTags)
+
+		// Watch history
+		protected.GET("/history", historyHandler.Timeline)
+		protected.POST("/history", historyHandler.Log)
+		protected.GET("/history/:id", historyHandler.Get)
+		protected.PATCH("/history/:id", historyHandler.Update)
+		protected.DELETE("/history/:id", historyHandler.Delete)
+
+		// Tags
+		protected.GET("/tags", tagHandler.GetAll)
+		protected.PATCH("/tags/:tagID", tagHandler.Rename)
+		protected.POST("/tags/:tagID/merge", tagHandler.Merge)
+		protected.DELETE("/tags/:tagID", tagHandler.Delete)
 
 		// Recommendations
 		protected.GET("/recommendations", recHandler.GetRecommendations)
 	}
 
+	// Admin routes (auth + admin role required)
+	admin := r.Group("/api/v1/admin")
+	admin.Use(middleware.AuthMiddleware(jwtSecret), middleware.AdminMiddleware())
+	{
+		admin.GET("/providers", adminHandler.GetProviders)
+		admin.GET("/providers/quota", adminHandler.GetProviderQuota)
+		admin.POST("/autocomplete/rebuild", adminHandler.RebuildAutocomplete)
+
+		// Movie curation
+		admin.PATCH("/movies/:imdbID", curationHandler.Edit)
+		admin.PUT("/movies/:imdbID/locks", curationHandler.SetLocks)
+		admin.PUT("/movies/:imdbID/hidden", curationHandler.SetHidden)
+		admin.POST("/movies/:imdbID/merge", curationHandler.Merge)
+		admin.GET("/movies/:imdbID/audit", curationHandler.AuditLog)
+	}
+
 	return r
 }
@@ -45,6 +45,7 @@ func (s *AuthService) Register(ctx context.Context, req *domain.RegisterRequest)
 		Username:     req.Username,
 		Email:        req.Email,
 		PasswordHash: string(hash),
+		Role:         domain.RoleUser,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
@@ -58,7 +59,7 @@ func (s *AuthService) Register(ctx context.Context, req *domain.RegisterRequest)
 	}
 
 	// Generate JWT
-	token, err := s.generateToken(user.ID)
+	token, err := s.generateToken(user)
 	if err != nil {
 		s.logger.Error("failed to generate token", zap.Error(err))
 		return nil, appErr.ErrInternal
@@ -83,7 +84,7 @@ func (s *AuthService) Login(ctx context.Context, req *domain.LoginRequest) (*dom
 		return nil, appErr.ErrInvalidCredentials
 	}
 
-	token, err := s.generateToken(user.ID)
+	token, err := s.generateToken(user)
 	if err != nil {
 		s.logger.Error("failed to generate token", zap.Error(err))
 		return nil, appErr.ErrInternal
@@ -92,9 +93,10 @@ func (s *AuthService) Login(ctx context.Context, req *domain.LoginRequest) (*dom
 	return &domain.AuthResponse{Token: token, User: *user}, nil
 }
 
-func (s *AuthService) generateToken(userID uuid.UUID) (string, error) {
+func (s *AuthService) generateToken(user *domain.User) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id": userID.String(),
+		"user_id": user.ID.String(),
+		"role":    string(user.Role),
 		"exp":     time.Now().Add(time.Duration(s.cfg.ExpiryHours) * time.Hour).Unix(),
 		"iat":     time.Now().Unix(),
 	}
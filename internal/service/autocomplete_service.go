@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+const (
+	autocompleteLimit = 10
+	// autocompleteMaxPrefix is the longest prefix indexed; longer queries
+	// are looked up by this prefix and filtered.
+	autocompleteMaxPrefix = 20
+	// autocompleteFetchLong is how many candidates are read for queries
+	// longer than autocompleteMaxPrefix, before filtering on the full query.
+	autocompleteFetchLong = 50
+	// autocompleteMaxWords bounds how many word starts of a title are
+	// indexed, so "dark kn" finds "The Dark Knight".
+	autocompleteMaxWords = 6
+	// autocompleteStartBonus ranks titles that begin with the query above
+	// those that only contain a word starting with it.
+	autocompleteStartBonus = 2
+
+	autocompleteBuiltKey     = "autocomplete:built"
+	autocompleteLockKey      = "autocomplete:rebuild:lock"
+	autocompleteLockTTL      = 30 * time.Minute
+	autocompleteRebuildBatch = 1000
+)
+
+// AutocompleteService serves title suggestions from a Redis prefix index.
+// Titles are indexed as movies are persisted; a full rebuild from the
+// movies table runs on first start and on demand.
+type AutocompleteService struct {
+	suggestRepo repository.SuggestionRepository
+	movieRepo   repository.MovieRepository
+	cache       repository.CacheRepository
+	logger      *zap.Logger
+}
+
+func NewAutocompleteService(
+	suggestRepo repository.SuggestionRepository,
+	movieRepo repository.MovieRepository,
+	cache repository.CacheRepository,
+	logger *zap.Logger,
+) *AutocompleteService {
+	return &AutocompleteService{
+		suggestRepo: suggestRepo,
+		movieRepo:   movieRepo,
+		cache:       cache,
+		logger:      logger,
+	}
+}
+
+// Suggest returns up to ten titles matching the start of query or the start
+// of a later word in the title.
+func (s *AutocompleteService) Suggest(ctx context.Context, query string) ([]domain.TitleSuggestion, error) {
+	norm := normalizeTitle(query)
+	if norm == "" {
+		return []domain.TitleSuggestion{}, nil
+	}
+
+	prefix, fetch := norm, autocompleteLimit
+	if r := []rune(norm); len(r) > autocompleteMaxPrefix {
+		prefix = strings.TrimRight(string(r[:autocompleteMaxPrefix]), " ")
+		fetch = autocompleteFetchLong
+	}
+
+	suggestions, err := s.suggestRepo.Suggest(ctx, prefix, fetch)
+	if err != nil {
+		s.logger.Error("autocomplete lookup failed", zap.String("prefix", prefix), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	results := make([]domain.TitleSuggestion, 0, autocompleteLimit)
+	for _, sg := range suggestions {
+		if prefix != norm && !strings.Contains(normalizeTitle(sg.Title), norm) {
+			continue
+		}
+		results = append(results, sg)
+		if len(results) == autocompleteLimit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// Add indexes a newly stored movie. Failures are logged, not returned, so
+// they never fail the request that stored the movie.
+func (s *AutocompleteService) Add(ctx context.Context, movie *domain.Movie) {
+	if err := s.suggestRepo.Index(ctx, []domain.SuggestionEntry{suggestionEntry(titleSuggestion(movie))}); err != nil {
+		s.logger.Warn("failed to index title for autocomplete", zap.String("imdbID", movie.ImdbID), zap.Error(err))
+	}
+}
+
+// Remove drops a movie from the index, e.g. when it is hidden or merged, or
+// before re-adding it under an edited title.
+func (s *AutocompleteService) Remove(ctx context.Context, movie *domain.Movie) {
+	entry := suggestionEntry(titleSuggestion(movie))
+	prefixes := make([]string, 0, len(entry.Prefixes))
+	for prefix := range entry.Prefixes {
+		prefixes = append(prefixes, prefix)
+	}
+	if err := s.suggestRepo.Remove(ctx, movie.ImdbID, prefixes); err != nil {
+		s.logger.Warn("failed to remove title from autocomplete", zap.String("imdbID", movie.ImdbID), zap.Error(err))
+	}
+}
+
+func titleSuggestion(movie *domain.Movie) domain.TitleSuggestion {
+	sg := domain.TitleSuggestion{
+		ImdbID: movie.ImdbID,
+		Title:  movie.Title,
+		Year:   movie.Year,
+		Type:   movie.Type,
+	}
+	if movie.ImdbScore != nil {
+		sg.Rank = *movie.ImdbScore
+	}
+	return sg
+}
+
+// EnsureBuilt rebuilds the index if it has never been built, e.g. on the
+// first start against an existing catalog.
+func (s *AutocompleteService) EnsureBuilt(ctx context.Context) {
+	built, err := s.cache.Get(ctx, autocompleteBuiltKey)
+	if err != nil {
+		s.logger.Warn("failed to check autocomplete index", zap.Error(err))
+		return
+	}
+	if built != "" {
+		return
+	}
+	if err := s.Rebuild(ctx); err != nil && !errors.Is(err, appErr.ErrAlreadyExists) {
+		s.logger.Error("autocomplete rebuild failed", zap.Error(err))
+	}
+}
+
+// Invalidate marks the index as never built, so the next EnsureBuilt
+// rebuilds it. Bulk loads that bypass Add call it when they cannot rebuild
+// the index themselves.
+func (s *AutocompleteService) Invalidate(ctx context.Context) error {
+	return s.cache.Delete(ctx, autocompleteBuiltKey)
+}
+
+// Rebuild re-indexes every stored title. Only one rebuild runs at a time
+// across instances; a concurrent call returns ErrAlreadyExists.
+func (s *AutocompleteService) Rebuild(ctx context.Context) error {
+	release, err := s.lockRebuild(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return s.rebuild(ctx)
+}
+
+// StartRebuild takes the rebuild lock and re-indexes in the background,
+// detached from ctx so it outlives the request that started it.
+func (s *AutocompleteService) StartRebuild(ctx context.Context) error {
+	release, err := s.lockRebuild(ctx)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer release()
+		if err := s.rebuild(context.WithoutCancel(ctx)); err != nil {
+			s.logger.Error("autocomplete rebuild failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (s *AutocompleteService) lockRebuild(ctx context.Context) (func(), error) {
+	token := uuid.New().String()
+	ok, err := s.cache.AcquireLock(ctx, autocompleteLockKey, token, autocompleteLockTTL)
+	if err != nil {
+		s.logger.Error("failed to acquire autocomplete lock", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if !ok {
+		return nil, appErr.New(409, "autocomplete rebuild already running", appErr.ErrAlreadyExists)
+	}
+	return func() {
+		if err := s.cache.ReleaseLock(context.WithoutCancel(ctx), autocompleteLockKey, token); err != nil {
+			s.logger.Warn("autocomplete lock release error", zap.Error(err))
+		}
+	}, nil
+}
+
+// rebuild indexes every stored title into a fresh generation and swaps it
+// in, so hidden, merged or renamed titles do not linger under old prefixes.
+func (s *AutocompleteService) rebuild(ctx context.Context) error {
+	start := time.Now()
+	gen, err := s.suggestRepo.BeginRebuild(ctx)
+	if err != nil {
+		return err
+	}
+	var after string
+	var indexed int
+	for {
+		titles, err := s.movieRepo.ListTitles(ctx, after, autocompleteRebuildBatch)
+		if err != nil {
+			return err
+		}
+		if len(titles) == 0 {
+			break
+		}
+
+		entries := make([]domain.SuggestionEntry, len(titles))
+		for i, t := range titles {
+			entries[i] = suggestionEntry(t)
+		}
+		if err := s.suggestRepo.IndexRebuild(ctx, gen, entries); err != nil {
+			return err
+		}
+		indexed += len(titles)
+		after = titles[len(titles)-1].ImdbID
+	}
+	if err := s.suggestRepo.CommitRebuild(ctx, gen); err != nil {
+		return err
+	}
+
+	if err := s.cache.Set(ctx, autocompleteBuiltKey, time.Now().UTC().Format(time.RFC3339), 0); err != nil {
+		s.logger.Warn("failed to mark autocomplete index built", zap.Error(err))
+	}
+	s.logger.Info("autocomplete index rebuilt", zap.Int("titles", indexed), zap.Duration("took", time.Since(start)))
+	return nil
+}
+
+// suggestionEntry files a title under every prefix of its normalized form
+// and of each later word start.
+func suggestionEntry(sg domain.TitleSuggestion) domain.SuggestionEntry {
+	entry := domain.SuggestionEntry{Suggestion: sg, Prefixes: make(map[string]float64)}
+	words := strings.Fields(normalizeTitle(sg.Title))
+	for i := 0; i < len(words) && i < autocompleteMaxWords; i++ {
+		rank := sg.Rank
+		if i == 0 {
+			rank += autocompleteStartBonus
+		}
+		runes := []rune(strings.Join(words[i:], " "))
+		for n := 1; n <= len(runes) && n <= autocompleteMaxPrefix; n++ {
+			if runes[n-1] == ' ' {
+				continue
+			}
+			prefix := string(runes[:n])
+			if cur, ok := entry.Prefixes[prefix]; !ok || rank > cur {
+				entry.Prefixes[prefix] = rank
+			}
+		}
+	}
+	return entry
+}
+
+// normalizeTitle lower-cases s and reduces punctuation and runs of spaces to
+// single spaces, so "Spider-Man: No Way Home" matches "spider man no".
+func normalizeTitle(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// auditListLimit bounds the audit entries returned for one movie.
+const auditListLimit = 100
+
+// CurationService lets admins correct stored movies: edit fields, lock them
+// against refreshes, hide titles and merge duplicates. Every change is
+// written together with its audit log entry, in one transaction.
+type CurationService struct {
+	movieRepo    repository.MovieRepository
+	auditRepo    repository.AuditRepository
+	statsService *StatsService
+	autocomplete *AutocompleteService
+	logger       *zap.Logger
+}
+
+func NewCurationService(
+	movieRepo repository.MovieRepository,
+	auditRepo repository.AuditRepository,
+	statsService *StatsService,
+	autocomplete *AutocompleteService,
+	logger *zap.Logger,
+) *CurationService {
+	return &CurationService{
+		movieRepo:    movieRepo,
+		auditRepo:    auditRepo,
+		statsService: statsService,
+		autocomplete: autocomplete,
+		logger:       logger,
+	}
+}
+
+// Edit applies an admin correction and locks every changed field so the
+// refresh worker does not revert it.
+func (s *CurationService) Edit(ctx context.Context, adminID uuid.UUID, imdbID string, req domain.MovieEditRequest) (*domain.Movie, error) {
+	movie, err := s.getStored(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	edited := *movie
+	if err := applyEdit(&edited, req); err != nil {
+		return nil, err
+	}
+	changes := fieldChanges(movie, &edited)
+	if len(changes) == 0 {
+		return movie, nil
+	}
+
+	audit := make(map[string]interface{}, len(changes)+1)
+	changed := make([]string, 0, len(changes))
+	for field, change := range changes {
+		audit[field] = change
+		changed = append(changed, field)
+	}
+	locks := mergeFields(movie.LockedFields, changed)
+	if len(locks) != len(movie.LockedFields) {
+		audit["locked_fields"] = domain.FieldChange{Old: joinFields(movie.LockedFields), New: joinFields(locks)}
+	}
+	edited.LockedFields = locks
+	edited.UpdatedAt = time.Now()
+
+	edit := domain.MovieEdit{
+		Movie: &edited,
+		Audit: auditEntry(adminID, &edited, domain.AuditEdit, audit),
+	}
+	if anyChanged(changes, "genre", "director", "writer", "actors") {
+		edit.SaveCredits = true
+		edit.Genres, edit.Credits = creditsFromMovie(&edited)
+	}
+	if err := s.movieRepo.SaveEdit(ctx, edit); err != nil {
+		s.logger.Error("failed to update movie", zap.String("imdbID", imdbID), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	if !movie.Hidden && anyChanged(changes, "title", "year", "type", "imdb_rating") {
+		s.autocomplete.Remove(ctx, movie)
+		s.autocomplete.Add(ctx, &edited)
+	}
+	return &edited, nil
+}
+
+// SetLocks replaces the fields the refresh worker must leave untouched.
+func (s *CurationService) SetLocks(ctx context.Context, adminID uuid.UUID, imdbID string, fields []string) (*domain.Movie, error) {
+	movie, err := s.getStored(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	locks := mergeFields(nil, fields)
+	if joinFields(locks) == joinFields(movie.LockedFields) {
+		return movie, nil
+	}
+	audit := auditEntry(adminID, movie, domain.AuditLock, map[string]interface{}{
+		"locked_fields": domain.FieldChange{Old: joinFields(movie.LockedFields), New: joinFields(locks)},
+	})
+	if err := s.movieRepo.SetLockedFields(ctx, movie.ID, locks, audit); err != nil {
+		s.logger.Error("failed to set locked fields", zap.String("imdbID", imdbID), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	movie.LockedFields = locks
+	return movie, nil
+}
+
+// SetHidden hides a movie from search, browse, recommendations, rankings and
+// autocomplete, or shows it again. Direct lookups and existing ratings and
+// watchlist entries are unaffected.
+func (s *CurationService) SetHidden(ctx context.Context, adminID uuid.UUID, imdbID string, hidden bool) (*domain.Movie, error) {
+	movie, err := s.getStored(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.Hidden == hidden {
+		return movie, nil
+	}
+
+	action := domain.AuditUnhide
+	if hidden {
+		action = domain.AuditHide
+	}
+	audit := auditEntry(adminID, movie, action, map[string]interface{}{})
+	if err := s.movieRepo.SetHidden(ctx, movie.ID, hidden, audit); err != nil {
+		s.logger.Error("failed to set movie visibility", zap.String("imdbID", imdbID), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	movie.Hidden = hidden
+
+	if hidden {
+		s.autocomplete.Remove(ctx, movie)
+	} else {
+		s.autocomplete.Add(ctx, movie)
+	}
+	return movie, nil
+}
+
+// Merge folds the duplicate imdbID into intoImdbID, re-pointing its ratings
+// and watchlist entries, and deletes the duplicate. Lookups of the old IMDb
+// ID resolve to the surviving movie afterwards.
+func (s *CurationService) Merge(ctx context.Context, adminID uuid.UUID, imdbID, intoImdbID string) (*domain.MergeResult, error) {
+	source, err := s.getStored(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getStored(ctx, intoImdbID)
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return nil, appErr.New(400, "cannot merge a movie into itself", appErr.ErrBadRequest)
+	}
+
+	audit := auditEntry(adminID, target, domain.AuditMerge, nil)
+	result, err := s.movieRepo.Merge(ctx, source.ID, target.ID, audit)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "movie not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to merge movies",
+			zap.String("source", source.ImdbID),
+			zap.String("target", target.ImdbID),
+			zap.Error(err),
+		)
+		return nil, appErr.ErrInternal
+	}
+	result.Movie = target
+
+	s.statsService.Invalidate(ctx, target.ID)
+	s.autocomplete.Remove(ctx, source)
+	return result, nil
+}
+
+// AuditLog returns the newest curation changes to a movie, including those
+// made to duplicates merged into it.
+func (s *CurationService) AuditLog(ctx context.Context, imdbID string) ([]domain.MovieAuditEntry, error) {
+	movie, err := s.getStored(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.auditRepo.ListByMovie(ctx, movie.ID, auditListLimit)
+	if err != nil {
+		s.logger.Error("failed to list audit log", zap.String("imdbID", imdbID), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if entries == nil {
+		entries = []domain.MovieAuditEntry{}
+	}
+	return entries, nil
+}
+
+// getStored loads a movie from the catalog without falling back to the
+// provider; admins only curate what is stored.
+func (s *CurationService) getStored(ctx context.Context, imdbID string) (*domain.Movie, error) {
+	movie, err := s.movieRepo.GetByImdbID(ctx, imdbID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "movie not found: "+imdbID, appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to get movie", zap.String("imdbID", imdbID), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return movie, nil
+}
+
+// auditEntry builds the audit log entry for a change. The repository writes
+// it in the same transaction as the change, so neither lands without the
+// other.
+func auditEntry(adminID uuid.UUID, movie *domain.Movie, action domain.AuditAction, changes map[string]interface{}) *domain.MovieAuditEntry {
+	return &domain.MovieAuditEntry{
+		ID:        uuid.New(),
+		MovieID:   movie.ID,
+		ImdbID:    movie.ImdbID,
+		AdminID:   &adminID,
+		Action:    action,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+}
+
+// applyEdit copies the fields present in req onto m, re-deriving the typed
+// columns that mirror string fields.
+func applyEdit(m *domain.Movie, req domain.MovieEditRequest) error {
+	setString := func(dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setString(&m.Title, req.Title)
+	setString(&m.Genre, req.Genre)
+	setString(&m.Director, req.Director)
+	setString(&m.Actors, req.Actors)
+	setString(&m.Plot, req.Plot)
+	setString(&m.PosterURL, req.PosterURL)
+	setString(&m.Rated, req.Rated)
+	setString(&m.Writer, req.Writer)
+	setString(&m.Language, req.Language)
+	setString(&m.Country, req.Country)
+	setString(&m.Awards, req.Awards)
+	setString(&m.Type, req.Type)
+
+	if req.Year != nil {
+		m.Year, m.ReleaseYear = *req.Year, parseYear(*req.Year)
+	}
+	if req.ImdbRating != nil {
+		m.ImdbRating, m.ImdbScore = *req.ImdbRating, parseScore(*req.ImdbRating)
+	}
+	if req.Released != nil {
+		released, err := time.Parse("2006-01-02", *req.Released)
+		if err != nil {
+			return appErr.New(400, "released must be a YYYY-MM-DD date", appErr.ErrBadRequest)
+		}
+		m.Released = &released
+	}
+	if req.RuntimeMinutes != nil {
+		m.RuntimeMinutes = req.RuntimeMinutes
+	}
+	if req.TotalSeasons != nil {
+		m.TotalSeasons = req.TotalSeasons
+	}
+	return nil
+}
+
+func anyChanged(changes map[string]domain.FieldChange, fields ...string) bool {
+	for _, f := range fields {
+		if _, ok := changes[f]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeFields returns the sorted union of two field lists.
+func mergeFields(a, b []string) []string {
+	set := make(map[string]bool, len(a)+len(b))
+	for _, f := range a {
+		set[f] = true
+	}
+	for _, f := range b {
+		set[f] = true
+	}
+	fields := make([]string, 0, len(set))
+	for f := range set {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func joinFields(fields []string) string {
+	sorted := append([]string(nil), fields...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
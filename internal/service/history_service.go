@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// defaultHistoryLimit is the timeline page size when a request sets none.
+const defaultHistoryLimit = 50
+
+var errFutureWatch = appErr.New(400, "watched_at must not be in the future", appErr.ErrBadRequest)
+
+// HistoryService keeps the user's watch history: one event per viewing, so
+// rewatches are recorded alongside the single watchlist status and rating.
+type HistoryService struct {
+	eventRepo    repository.WatchEventRepository
+	ratingRepo   repository.RatingRepository
+	movieService *MovieService
+	logger       *zap.Logger
+}
+
+func NewHistoryService(
+	eventRepo repository.WatchEventRepository,
+	ratingRepo repository.RatingRepository,
+	movieService *MovieService,
+	logger *zap.Logger,
+) *HistoryService {
+	return &HistoryService{
+		eventRepo:    eventRepo,
+		ratingRepo:   ratingRepo,
+		movieService: movieService,
+		logger:       logger,
+	}
+}
+
+// Log records a viewing. Fetches the movie from OMDb if not in DB.
+func (s *HistoryService) Log(ctx context.Context, userID uuid.UUID, req *domain.CreateWatchEventRequest) (*domain.WatchEvent, error) {
+	movie, err := s.movieService.GetByImdbID(ctx, req.ImdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	watchedAt := now
+	if req.WatchedAt != nil {
+		watchedAt = *req.WatchedAt
+	}
+	if watchedAt.After(now) {
+		return nil, errFutureWatch
+	}
+	companions, err := normalizeCompanions(req.Companions)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &domain.WatchEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		MovieID:    movie.ID,
+		WatchedAt:  watchedAt,
+		Rating:     req.Rating,
+		Venue:      req.Venue,
+		Companions: companions,
+		CreatedAt:  now,
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		s.logger.Error("failed to log watch event", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return s.reload(ctx, event.ID)
+}
+
+// LogWatched records a viewing for a watchlist entry that just moved to
+// watched, with the user's current rating as the snapshot. The status change
+// has already been applied, so failures are logged rather than returned.
+func (s *HistoryService) LogWatched(ctx context.Context, entry *domain.Watchlist) {
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now()
+	event := &domain.WatchEvent{
+		ID:          uuid.New(),
+		UserID:      entry.UserID,
+		MovieID:     entry.MovieID,
+		WatchlistID: &entry.ID,
+		WatchedAt:   now,
+		Companions:  []string{},
+		CreatedAt:   now,
+	}
+
+	rating, err := s.ratingRepo.GetByUserAndMovie(ctx, entry.UserID, entry.MovieID)
+	switch {
+	case err == nil:
+		event.Rating = &rating.Score
+	case !errors.Is(err, appErr.ErrNotFound):
+		s.logger.Warn("failed to snapshot rating for watch event", zap.Error(err))
+	}
+
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		s.logger.Error("failed to log watch event",
+			zap.String("entry_id", entry.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// Get returns one of the user's viewings.
+func (s *HistoryService) Get(ctx context.Context, userID, eventID uuid.UUID) (*domain.WatchEvent, error) {
+	return s.owned(ctx, userID, eventID)
+}
+
+// Update changes a logged viewing. Only the fields present in req change.
+func (s *HistoryService) Update(ctx context.Context, userID, eventID uuid.UUID, req *domain.UpdateWatchEventRequest) (*domain.WatchEvent, error) {
+	event, err := s.owned(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.WatchedAt != nil {
+		if req.WatchedAt.After(time.Now()) {
+			return nil, errFutureWatch
+		}
+		event.WatchedAt = *req.WatchedAt
+	}
+	if req.Rating != nil {
+		event.Rating = req.Rating
+		if *req.Rating == 0 {
+			event.Rating = nil
+		}
+	}
+	if req.Venue != nil {
+		event.Venue = *req.Venue
+	}
+	if req.Companions != nil {
+		if event.Companions, err = normalizeCompanions(req.Companions); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.ErrNotFound
+		}
+		s.logger.Error("failed to update watch event", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	// Moving a viewing in time can change its watch number.
+	return s.reload(ctx, eventID)
+}
+
+// Delete removes a logged viewing.
+func (s *HistoryService) Delete(ctx context.Context, userID, eventID uuid.UUID) error {
+	if _, err := s.owned(ctx, userID, eventID); err != nil {
+		return err
+	}
+	if err := s.eventRepo.Delete(ctx, eventID); err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return appErr.ErrNotFound
+		}
+		s.logger.Error("failed to delete watch event", zap.Error(err))
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// Timeline returns the user's viewings newest first, one keyset page at a
+// time.
+func (s *HistoryService) Timeline(ctx context.Context, userID uuid.UUID, req domain.WatchHistoryRequest) (*domain.WatchHistoryPage, error) {
+	if req.Limit == 0 {
+		req.Limit = defaultHistoryLimit
+	}
+	if !req.From.IsZero() && !req.To.IsZero() && req.From.After(req.To) {
+		return nil, appErr.New(400, "from must not be after to", appErr.ErrBadRequest)
+	}
+
+	q := domain.WatchHistoryQuery{
+		UserID: userID,
+		ImdbID: req.ImdbID,
+		From:   req.From,
+		Limit:  req.Limit + 1,
+	}
+	if !req.To.IsZero() {
+		q.Before = req.To.AddDate(0, 0, 1)
+	}
+	if req.Cursor != "" {
+		var cursor domain.WatchEventCursor
+		if err := decodeCursor(req.Cursor, &cursor); err != nil {
+			return nil, appErr.New(400, "invalid cursor", appErr.ErrBadRequest)
+		}
+		q.After = &cursor
+	}
+
+	events, err := s.eventRepo.List(ctx, q)
+	if err != nil {
+		s.logger.Error("failed to list watch history", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	page := &domain.WatchHistoryPage{Events: events}
+	if len(events) > req.Limit {
+		page.Events = events[:req.Limit]
+		last := &page.Events[req.Limit-1]
+		page.NextCursor = encodeCursor(&domain.WatchEventCursor{WatchedAt: last.WatchedAt, ID: last.ID})
+	}
+	if page.Events == nil {
+		page.Events = []domain.WatchEvent{}
+	}
+	return page, nil
+}
+
+// owned loads a watch event and verifies it belongs to userID.
+func (s *HistoryService) owned(ctx context.Context, userID, eventID uuid.UUID) (*domain.WatchEvent, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.ErrNotFound
+		}
+		s.logger.Error("failed to get watch event", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if event.UserID != userID {
+		return nil, appErr.ErrForbidden
+	}
+	return event, nil
+}
+
+// reload returns a stored event with its movie and watch number.
+func (s *HistoryService) reload(ctx context.Context, eventID uuid.UUID) (*domain.WatchEvent, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		s.logger.Error("failed to get watch event", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return event, nil
+}
+
+// normalizeCompanions trims companion names, rejecting blank ones.
+func normalizeCompanions(names []string) ([]string, error) {
+	companions := make([]string, 0, len(names))
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			return nil, appErr.New(400, "companion names must not be blank", appErr.ErrBadRequest)
+		}
+		companions = append(companions, n)
+	}
+	return companions, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+var errListNameTaken = appErr.New(409, "a list with that name already exists", appErr.ErrAlreadyExists)
+
+// ListService manages a user's named watchlists. The default list is created
+// on first use, so users who never touch lists keep a single watchlist.
+type ListService struct {
+	listRepo      repository.ListRepository
+	watchlistRepo repository.WatchlistRepository
+	statsService  *StatsService
+	logger        *zap.Logger
+}
+
+func NewListService(
+	listRepo repository.ListRepository,
+	watchlistRepo repository.WatchlistRepository,
+	statsService *StatsService,
+	logger *zap.Logger,
+) *ListService {
+	return &ListService{
+		listRepo:      listRepo,
+		watchlistRepo: watchlistRepo,
+		statsService:  statsService,
+		logger:        logger,
+	}
+}
+
+// GetAll returns the user's lists in their display order.
+func (s *ListService) GetAll(ctx context.Context, userID uuid.UUID) ([]domain.UserList, error) {
+	if _, err := s.Default(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	lists, err := s.listRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get lists", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return lists, nil
+}
+
+// Default returns the user's default list, creating it if needed.
+func (s *ListService) Default(ctx context.Context, userID uuid.UUID) (*domain.UserList, error) {
+	list, err := s.listRepo.EnsureDefault(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get default list", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return list, nil
+}
+
+// Get returns one of the user's lists.
+func (s *ListService) Get(ctx context.Context, userID, listID uuid.UUID) (*domain.UserList, error) {
+	list, err := s.listRepo.GetByID(ctx, listID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "list not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to get list", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if list.UserID != userID {
+		return nil, appErr.ErrForbidden
+	}
+	return list, nil
+}
+
+// Create adds a list after the user's existing ones.
+func (s *ListService) Create(ctx context.Context, userID uuid.UUID, req *domain.CreateListRequest) (*domain.UserList, error) {
+	name, err := listName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	// The default list is created first so it cannot lose its name to a
+	// user-created list.
+	if _, err := s.Default(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	list := &domain.UserList{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if err := s.listRepo.Create(ctx, list); err != nil {
+		if errors.Is(err, appErr.ErrAlreadyExists) {
+			return nil, errListNameTaken
+		}
+		s.logger.Error("failed to create list", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return list, nil
+}
+
+// Rename changes a list's name. The default list can be renamed too.
+func (s *ListService) Rename(ctx context.Context, userID, listID uuid.UUID, req *domain.RenameListRequest) (*domain.UserList, error) {
+	name, err := listName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.Get(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.listRepo.Rename(ctx, listID, name); err != nil {
+		if errors.Is(err, appErr.ErrAlreadyExists) {
+			return nil, errListNameTaken
+		}
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "list not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to rename list", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	list.Name = name
+	return list, nil
+}
+
+// Reorder sets the display order of the user's lists. ids must name every
+// one of the user's lists exactly once.
+func (s *ListService) Reorder(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]domain.UserList, error) {
+	lists, err := s.GetAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make(map[uuid.UUID]bool, len(lists))
+	for _, l := range lists {
+		owned[l.ID] = true
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !owned[id] || seen[id] {
+			return nil, appErr.New(400, "list_ids must contain each of your lists exactly once", appErr.ErrBadRequest)
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(owned) {
+		return nil, appErr.New(400, "list_ids must contain each of your lists exactly once", appErr.ErrBadRequest)
+	}
+
+	if err := s.listRepo.Reorder(ctx, userID, ids); err != nil {
+		s.logger.Error("failed to reorder lists", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return s.GetAll(ctx, userID)
+}
+
+// Delete removes a list and its entries. The default list cannot be deleted.
+func (s *ListService) Delete(ctx context.Context, userID, listID uuid.UUID) error {
+	list, err := s.Get(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+	if list.IsDefault {
+		return appErr.New(400, "the default list cannot be deleted", appErr.ErrBadRequest)
+	}
+
+	movieIDs, err := s.watchlistRepo.ListMovieIDs(ctx, listID)
+	if err != nil {
+		s.logger.Error("failed to get list movies", zap.Error(err))
+		return appErr.ErrInternal
+	}
+	if err := s.listRepo.Delete(ctx, listID); err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return appErr.New(404, "list not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to delete list", zap.Error(err))
+		return appErr.ErrInternal
+	}
+	for _, id := range movieIDs {
+		s.statsService.Invalidate(ctx, id)
+	}
+	return nil
+}
+
+func listName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", appErr.New(400, "list name must not be blank", appErr.ErrBadRequest)
+	}
+	return name, nil
+}
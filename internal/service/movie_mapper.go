@@ -0,0 +1,159 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+var (
+	runtimePattern = regexp.MustCompile(`^(\d+)\s*min`)
+	yearPattern    = regexp.MustCompile(`^(\d{4})`)
+)
+
+// movieFromDetail converts an OMDb-shaped detail into a new Movie, parsing the
+// string fields OMDb returns into typed values where possible.
+func movieFromDetail(detail *domain.OMDbMovieDetail) *domain.Movie {
+	now := time.Now()
+	return &domain.Movie{
+		ID:             uuid.New(),
+		ImdbID:         detail.ImdbID,
+		Title:          detail.Title,
+		Year:           detail.Year,
+		Genre:          detail.Genre,
+		Director:       detail.Director,
+		Actors:         detail.Actors,
+		Plot:           detail.Plot,
+		PosterURL:      detail.Poster,
+		ImdbRating:     detail.ImdbRating,
+		Rated:          notAvailable(detail.Rated),
+		Released:       parseReleased(detail.Released),
+		RuntimeMinutes: parseRuntime(detail.Runtime),
+		Writer:         notAvailable(detail.Writer),
+		Language:       notAvailable(detail.Language),
+		Country:        notAvailable(detail.Country),
+		Awards:         notAvailable(detail.Awards),
+		Type:           notAvailable(detail.Type),
+		ReleaseYear:    parseYear(detail.Year),
+		ImdbScore:      parseScore(detail.ImdbRating),
+		TotalSeasons:   parsePositive(detail.TotalSeasons),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		RefreshedAt:    now,
+	}
+}
+
+// notAvailable maps OMDb's "N/A" placeholder to an empty string.
+func notAvailable(s string) string {
+	if s == "N/A" {
+		return ""
+	}
+	return s
+}
+
+// parseRuntime turns "148 min" into 148.
+func parseRuntime(s string) *int {
+	m := runtimePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n <= 0 {
+		return nil
+	}
+	return &n
+}
+
+// parseReleased turns OMDb's "16 Jul 2010" into a date.
+func parseReleased(s string) *time.Time {
+	t, err := time.Parse("02 Jan 2006", strings.TrimSpace(s))
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// parseYear extracts the first year from "2010", "2008–2013" or "2013–".
+func parseYear(s string) *int {
+	m := yearPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil
+	}
+	n, _ := strconv.Atoi(m[1])
+	return &n
+}
+
+// parseScore turns "8.8" into 8.8.
+func parseScore(s string) *float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || f < 0 || f > 10 {
+		return nil
+	}
+	return &f
+}
+
+// creditsFromDetail splits OMDb's comma-separated genre and people fields.
+// Writer entries carry annotations like "Jonathan Nolan (story)", which are
+// stripped so the same person maps to a single row.
+func creditsFromDetail(detail *domain.OMDbMovieDetail) ([]string, []domain.Credit) {
+	genres := splitList(detail.Genre)
+
+	var credits []domain.Credit
+	seen := make(map[string]bool)
+	add := func(list string, role domain.CreditRole) {
+		for i, name := range splitList(list) {
+			key := string(role) + "|" + name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			credits = append(credits, domain.Credit{Name: name, Role: role, BillingOrder: i})
+		}
+	}
+	add(detail.Director, domain.CreditDirector)
+	add(detail.Writer, domain.CreditWriter)
+	add(detail.Actors, domain.CreditActor)
+
+	return genres, credits
+}
+
+// creditsFromMovie derives genres and credits from a stored movie's own
+// fields, e.g. after an admin edit.
+func creditsFromMovie(m *domain.Movie) ([]string, []domain.Credit) {
+	return creditsFromDetail(&domain.OMDbMovieDetail{
+		Genre:    m.Genre,
+		Director: m.Director,
+		Writer:   m.Writer,
+		Actors:   m.Actors,
+	})
+}
+
+// splitList splits "A, B (note), N/A" into ["A", "B"].
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if i := strings.Index(item, "("); i >= 0 {
+			item = item[:i]
+		}
+		item = strings.TrimSpace(item)
+		if item == "" || item == "N/A" {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// parsePositive parses a positive integer such as OMDb's totalSeasons.
+func parsePositive(s string) *int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n <= 0 {
+		return nil
+	}
+	return &n
+}
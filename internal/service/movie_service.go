@@ -2,101 +2,139 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/namru/movie-recommend/internal/config"
 	"github.com/namru/movie-recommend/internal/domain"
 	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/provider"
 	"github.com/namru/movie-recommend/internal/repository"
 )
 
+const (
+	// movieFetchTimeout bounds a coalesced upstream fetch.
+	movieFetchTimeout = 30 * time.Second
+	// movieLockPollInterval is how often lock waiters re-check the DB.
+	movieLockPollInterval = 100 * time.Millisecond
+	// batchFetchWorkers bounds concurrent provider lookups in GetBatch.
+	batchFetchWorkers = 5
+)
+
+var imdbIDPattern = regexp.MustCompile(`^tt\d{7,10}$`)
+
 type MovieService struct {
-	movieRepo repository.MovieRepository
-	cache     repository.CacheRepository
-	cfg       *config.Config
-	logger    *zap.Logger
-	client    *http.Client
+	movieRepo    repository.MovieRepository
+	cache        repository.CacheRepository
+	provider     provider.MovieProvider
+	autocomplete *AutocompleteService
+	cfg          *config.Config
+	logger       *zap.Logger
+	lookups      singleflight.Group
 }
 
 func NewMovieService(
 	movieRepo repository.MovieRepository,
 	cache repository.CacheRepository,
+	movieProvider provider.MovieProvider,
+	autocomplete *AutocompleteService,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) *MovieService {
 	return &MovieService{
-		movieRepo: movieRepo,
-		cache:     cache,
-		cfg:       cfg,
-		logger:    logger,
-		client:    &http.Client{Timeout: 10 * time.Second},
+		movieRepo:    movieRepo,
+		cache:        cache,
+		provider:     movieProvider,
+		autocomplete: autocomplete,
+		cfg:          cfg,
+		logger:       logger,
 	}
 }
 
-// Search queries OMDb for movies by title (with Redis caching).
-func (s *MovieService) Search(ctx context.Context, query string, page int) (*domain.OMDbSearchResponse, error) {
-	if page <= 0 {
-		page = 1
+// Search queries the movie provider for movies by title (with Redis caching).
+// Movies hidden by an admin are dropped from each page.
+func (s *MovieService) Search(ctx context.Context, req domain.MovieSearchRequest) (*domain.MovieSearchResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
 	}
 
 	// Check cache
-	cacheKey := fmt.Sprintf("omdb:search:%s:%d", query, page)
+	cacheKey := fmt.Sprintf("movies:search:%s:%s:%d:%d", req.Query, req.Type, req.Year, req.Page)
 	cached, err := s.cache.Get(ctx, cacheKey)
 	if err != nil {
 		s.logger.Warn("cache get error", zap.Error(err))
 	}
 	if cached != "" {
-		var result domain.OMDbSearchResponse
+		var result domain.MovieSearchResponse
 		if err := json.Unmarshal([]byte(cached), &result); err == nil {
 			s.logger.Debug("cache hit", zap.String("key", cacheKey))
-			return &result, nil
+			return s.dropHidden(ctx, &result)
 		}
 	}
 
-	// Call OMDb API
-	url := fmt.Sprintf("%s/?apikey=%s&s=%s&page=%d",
-		s.cfg.OMDB.BaseURL, s.cfg.OMDB.APIKey, query, page,
-	)
-
-	resp, err := s.client.Get(url)
+	// Call the provider
+	result, err := s.provider.Search(ctx, req)
 	if err != nil {
-		s.logger.Error("omdb api call failed", zap.Error(err))
-		return nil, appErr.ErrExternalAPI
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	// Cache the result unfiltered; hiding takes effect without invalidation.
+	s.cacheJSON(ctx, cacheKey, result, s.cfg.Cache.SearchTTL)
+
+	return s.dropHidden(ctx, result)
+}
+
+// dropHidden removes provider search hits for movies hidden in the catalog.
+// The totals are left as the provider reported them: pages map one-to-one to
+// provider pages, so hiding hits never removes a page, and the true count of
+// hidden movies beyond this page is unknown.
+func (s *MovieService) dropHidden(ctx context.Context, result *domain.MovieSearchResponse) (*domain.MovieSearchResponse, error) {
+	if len(result.Results) == 0 {
+		return result, nil
+	}
+	ids := make([]string, len(result.Results))
+	for i, r := range result.Results {
+		ids[i] = r.ImdbID
+	}
+	hidden, err := s.movieRepo.ListHiddenImdbIDs(ctx, ids)
 	if err != nil {
-		s.logger.Error("failed to read omdb response", zap.Error(err))
-		return nil, appErr.ErrExternalAPI
+		s.logger.Error("failed to look up hidden movies", zap.Error(err))
+		return nil, appErr.ErrInternal
 	}
-
-	var result domain.OMDbSearchResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		s.logger.Error("failed to parse omdb response", zap.Error(err))
-		return nil, appErr.ErrExternalAPI
+	if len(hidden) == 0 {
+		return result, nil
 	}
 
-	if result.Response == "False" {
-		return nil, appErr.New(404, "no movies found: "+result.Error, appErr.ErrNotFound)
+	skip := make(map[string]bool, len(hidden))
+	for _, id := range hidden {
+		skip[id] = true
 	}
-
-	// Cache the result
-	if err := s.cache.Set(ctx, cacheKey, string(body), s.cfg.Cache.SearchTTL); err != nil {
-		s.logger.Warn("cache set error", zap.Error(err))
+	kept := result.Results[:0]
+	for _, r := range result.Results {
+		if !skip[r.ImdbID] {
+			kept = append(kept, r)
+		}
 	}
-
-	return &result, nil
+	result.Results = kept
+	return result, nil
 }
 
-// GetByImdbID fetches full movie details from OMDb (with caching) and persists to DB.
+// GetByImdbID fetches full movie details from the provider (with caching) and persists to DB.
+//
+// Concurrent lookups for the same imdbID that miss the DB are coalesced: one
+// goroutine performs the fetch and every waiter receives its result. With
+// Cache.MovieLock enabled, a Redis lock extends this across instances.
 func (s *MovieService) GetByImdbID(ctx context.Context, imdbID string) (*domain.Movie, error) {
 	// Check DB first
 	movie, err := s.movieRepo.GetByImdbID(ctx, imdbID)
@@ -104,78 +142,359 @@ func (s *MovieService) GetByImdbID(ctx context.Context, imdbID string) (*domain.
 		return movie, nil
 	}
 
+	// The shared fetch must outlive any single caller, so it runs detached from
+	// the caller's cancellation and each waiter gives up on its own ctx.
+	ch := s.lookups.DoChan(imdbID, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), movieFetchTimeout)
+		defer cancel()
+		return s.fetchMovie(fetchCtx, imdbID)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		if res.Shared {
+			s.logger.Debug("coalesced movie lookup", zap.String("imdbID", imdbID))
+		}
+		// Hand each waiter its own copy.
+		m := *res.Val.(*domain.Movie)
+		return &m, nil
+	}
+}
+
+// Browse lists stored movies matching the request filters, one keyset page
+// at a time. Cursors are opaque to clients and only valid for the sort they
+// were issued with.
+func (s *MovieService) Browse(ctx context.Context, req domain.MovieListRequest) (*domain.MovieListResponse, error) {
+	if req.Sort == "" {
+		req.Sort = domain.SortRecent
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+	if req.YearFrom != 0 && req.YearTo != 0 && req.YearFrom > req.YearTo {
+		return nil, appErr.New(400, "year_from must not be after year_to", appErr.ErrBadRequest)
+	}
+
+	q := domain.MovieListQuery{Filter: req, Limit: req.Limit + 1}
+	if req.Cursor != "" {
+		var cursor domain.MovieCursor
+		if err := decodeCursor(req.Cursor, &cursor); err != nil {
+			return nil, appErr.New(400, "invalid cursor", appErr.ErrBadRequest)
+		}
+		if cursor.Sort != req.Sort {
+			return nil, appErr.New(400, "cursor does not match sort", appErr.ErrBadRequest)
+		}
+		q.After = &cursor
+	}
+
+	movies, err := s.movieRepo.List(ctx, q)
+	if err != nil {
+		s.logger.Error("failed to list movies", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	resp := &domain.MovieListResponse{Movies: movies}
+	if len(movies) > req.Limit {
+		resp.Movies = movies[:req.Limit]
+		last := &resp.Movies[req.Limit-1]
+		resp.NextCursor = encodeCursor(&domain.MovieCursor{
+			Sort:  req.Sort,
+			Value: last.SortValue(req.Sort),
+			ID:    last.ID,
+		})
+	}
+	if resp.Movies == nil {
+		resp.Movies = []domain.CatalogMovie{}
+	}
+	return resp, nil
+}
+
+// encodeCursor serializes a keyset cursor into an opaque URL-safe token.
+func encodeCursor(c interface{}) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a token produced by encodeCursor into c.
+func decodeCursor(s string, c interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, c)
+}
+
+// GetBatch looks up several movies at once. Stored movies are loaded with a
+// single query; the rest are fetched through GetByImdbID by a bounded pool of
+// workers. Results follow the order of imdbIDs, with duplicates collapsed,
+// and a failure for one ID does not affect the others.
+func (s *MovieService) GetBatch(ctx context.Context, imdbIDs []string) (*domain.BatchMovieResponse, error) {
+	var ids []string
+	seen := make(map[string]bool, len(imdbIDs))
+	for _, id := range imdbIDs {
+		id = strings.TrimSpace(id)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	results := make([]domain.BatchMovieResult, len(ids))
+	var valid []string
+	for i, id := range ids {
+		results[i].ImdbID = id
+		if imdbIDPattern.MatchString(id) {
+			valid = append(valid, id)
+		} else {
+			results[i].Status = http.StatusBadRequest
+			results[i].Error = "invalid IMDb ID"
+		}
+	}
+
+	known := make(map[string]*domain.Movie, len(valid))
+	if len(valid) > 0 {
+		movies, err := s.movieRepo.GetByImdbIDs(ctx, valid)
+		if err != nil {
+			s.logger.Error("failed to load movies", zap.Error(err))
+			return nil, appErr.ErrInternal
+		}
+		for i := range movies {
+			known[movies[i].ImdbID] = &movies[i]
+		}
+	}
+
+	var missing []int
+	for i := range results {
+		if results[i].Error != "" {
+			continue
+		}
+		if m, ok := known[results[i].ImdbID]; ok {
+			results[i].Movie, results[i].Status = m, http.StatusOK
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < batchFetchWorkers && w < len(missing); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				movie, err := s.GetByImdbID(ctx, results[i].ImdbID)
+				if err != nil {
+					results[i].Status = appErr.MapToHTTPStatus(err)
+					results[i].Error = err.Error()
+					continue
+				}
+				results[i].Movie, results[i].Status = movie, http.StatusOK
+			}
+		}()
+	}
+	for _, i := range missing {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	resp := &domain.BatchMovieResponse{Results: results}
+	for _, r := range results {
+		if r.Movie != nil {
+			resp.Found++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
+
+// fetchMovie resolves a movie missing from the DB via the cache or provider,
+// holding the distributed lock (if enabled) for the duration.
+func (s *MovieService) fetchMovie(ctx context.Context, imdbID string) (*domain.Movie, error) {
+	if s.cfg.Cache.MovieLock {
+		release, movie := s.acquireMovieLock(ctx, imdbID)
+		if movie != nil {
+			return movie, nil
+		}
+		defer release()
+
+		// Another instance may have persisted it while we were waiting.
+		if movie, err := s.movieRepo.GetByImdbID(ctx, imdbID); err == nil {
+			return movie, nil
+		}
+	}
+
 	// Check cache
-	cacheKey := fmt.Sprintf("omdb:movie:%s", imdbID)
+	cacheKey := movieCacheKey(imdbID)
 	cached, err := s.cache.Get(ctx, cacheKey)
 	if err != nil {
 		s.logger.Warn("cache get error", zap.Error(err))
 	}
 
-	var detail domain.OMDbMovieDetail
 	if cached != "" {
+		var detail domain.OMDbMovieDetail
 		if err := json.Unmarshal([]byte(cached), &detail); err == nil {
 			s.logger.Debug("cache hit for movie detail", zap.String("imdbID", imdbID))
-			return s.persistMovie(&detail)
+			return s.persistMovie(ctx, &detail)
 		}
 	}
 
-	// Call OMDb API
-	url := fmt.Sprintf("%s/?apikey=%s&i=%s&plot=full",
-		s.cfg.OMDB.BaseURL, s.cfg.OMDB.APIKey, imdbID,
-	)
-
-	resp, err := s.client.Get(url)
+	// Call the provider
+	detail, err := s.provider.GetByExternalID(ctx, imdbID)
 	if err != nil {
-		s.logger.Error("omdb api call failed", zap.Error(err))
-		return nil, appErr.ErrExternalAPI
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, appErr.ErrExternalAPI
+	// Cache
+	s.cacheJSON(ctx, cacheKey, detail, s.cfg.Cache.MovieTTL)
+
+	return s.persistMovie(ctx, detail)
+}
+
+// movieCacheKey is the cache key of a provider movie payload.
+func movieCacheKey(imdbID string) string {
+	return fmt.Sprintf("omdb:movie:%s", imdbID)
+}
+
+// acquireMovieLock takes the cross-instance lock for imdbID. If another
+// instance holds it, it waits for that instance to persist the movie and
+// returns it. On timeout or Redis errors it proceeds without the lock, since
+// a duplicate upstream call is preferable to failing the request.
+func (s *MovieService) acquireMovieLock(ctx context.Context, imdbID string) (func(), *domain.Movie) {
+	key := fmt.Sprintf("lock:movie:%s", imdbID)
+	token := uuid.New().String()
+	noop := func() {}
+
+	deadline := time.Now().Add(s.cfg.Cache.MovieLockTTL)
+	for {
+		ok, err := s.cache.AcquireLock(ctx, key, token, s.cfg.Cache.MovieLockTTL)
+		if err != nil {
+			s.logger.Warn("movie lock acquire error", zap.String("imdbID", imdbID), zap.Error(err))
+			return noop, nil
+		}
+		if ok {
+			return func() {
+				if err := s.cache.ReleaseLock(context.WithoutCancel(ctx), key, token); err != nil {
+					s.logger.Warn("movie lock release error", zap.String("imdbID", imdbID), zap.Error(err))
+				}
+			}, nil
+		}
+
+		if movie, err := s.movieRepo.GetByImdbID(ctx, imdbID); err == nil {
+			return noop, movie
+		}
+		if time.Now().After(deadline) {
+			s.logger.Warn("timed out waiting for movie lock", zap.String("imdbID", imdbID))
+			return noop, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return noop, nil
+		case <-time.After(movieLockPollInterval):
+		}
 	}
+}
 
-	if err := json.Unmarshal(body, &detail); err != nil {
-		return nil, appErr.ErrExternalAPI
+// SearchCatalog searches movies already stored in the local database, without
+// touching the external provider or its quota.
+func (s *MovieService) SearchCatalog(ctx context.Context, req domain.CatalogSearchRequest) (*domain.CatalogSearchResponse, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
 	}
 
-	if detail.Response == "False" {
-		return nil, appErr.New(404, "movie not found: "+detail.Error, appErr.ErrNotFound)
+	movies, total, err := s.movieRepo.Search(ctx, req.Query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		s.logger.Error("catalog search failed", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if movies == nil {
+		movies = []domain.Movie{}
 	}
 
-	// Cache
-	if err := s.cache.Set(ctx, cacheKey, string(body), s.cfg.Cache.MovieTTL); err != nil {
-		s.logger.Warn("cache set error", zap.Error(err))
+	return &domain.CatalogSearchResponse{
+		Movies:       movies,
+		Page:         page,
+		PageSize:     pageSize,
+		TotalResults: total,
+		TotalPages:   (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// ProviderHealth reports the health of each configured movie provider.
+func (s *MovieService) ProviderHealth() []provider.Health {
+	if r, ok := s.provider.(provider.HealthReporter); ok {
+		return r.Health()
 	}
+	return []provider.Health{{Name: s.provider.Name(), Healthy: true}}
+}
 
-	return s.persistMovie(&detail)
+// fetchSeason looks up one season of a series through the provider.
+func (s *MovieService) fetchSeason(ctx context.Context, imdbID string, season int) (*domain.OMDbSeasonResponse, error) {
+	sp, ok := s.provider.(provider.SeasonProvider)
+	if !ok {
+		return nil, appErr.New(400, "the movie provider does not support seasons", appErr.ErrBadRequest)
+	}
+	return sp.GetSeason(ctx, imdbID, season)
 }
 
-// persistMovie saves the OMDb movie detail to the database.
-func (s *MovieService) persistMovie(detail *domain.OMDbMovieDetail) (*domain.Movie, error) {
-	movie := &domain.Movie{
-		ID:         uuid.New(),
-		ImdbID:     detail.ImdbID,
-		Title:      detail.Title,
-		Year:       detail.Year,
-		Genre:      detail.Genre,
-		Director:   detail.Director,
-		Actors:     detail.Actors,
-		Plot:       detail.Plot,
-		PosterURL:  detail.Poster,
-		ImdbRating: detail.ImdbRating,
-		CreatedAt:  time.Now(),
+// ProviderQuota reports today's API key usage for providers with metered keys.
+func (s *MovieService) ProviderQuota(ctx context.Context) ([]domain.APIKeyQuota, error) {
+	r, ok := s.provider.(provider.QuotaReporter)
+	if !ok {
+		return []domain.APIKeyQuota{}, nil
 	}
+	quota, err := r.Quota(ctx)
+	if err != nil {
+		s.logger.Error("failed to read provider quota", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return quota, nil
+}
 
-	if err := s.movieRepo.Create(context.Background(), movie); err != nil {
-		s.logger.Warn("failed to persist movie (may already exist)", zap.Error(err))
+// cacheJSON stores value in the cache as JSON, logging (not returning) failures.
+func (s *MovieService) cacheJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		s.logger.Warn("failed to marshal cache value", zap.String("key", key), zap.Error(err))
+		return
+	}
+	if err := s.cache.Set(ctx, key, string(data), ttl); err != nil {
+		s.logger.Warn("cache set error", zap.Error(err))
+	}
+}
+
+// persistMovie saves the provider's movie detail to the database.
+func (s *MovieService) persistMovie(ctx context.Context, detail *domain.OMDbMovieDetail) (*domain.Movie, error) {
+	movie := movieFromDetail(detail)
+
+	if err := s.movieRepo.Create(ctx, movie); err != nil {
+		if !errors.Is(err, appErr.ErrAlreadyExists) {
+			s.logger.Warn("failed to persist movie", zap.Error(err))
+		}
 		// Try to return the existing movie from DB
-		existing, dbErr := s.movieRepo.GetByImdbID(context.Background(), detail.ImdbID)
+		existing, dbErr := s.movieRepo.GetByImdbID(ctx, detail.ImdbID)
 		if dbErr == nil {
 			return existing, nil
 		}
+		return movie, nil
+	}
+
+	genres, credits := creditsFromDetail(detail)
+	if err := s.movieRepo.SaveCredits(ctx, movie.ID, genres, credits); err != nil {
+		s.logger.Warn("failed to save movie credits", zap.String("imdbID", movie.ImdbID), zap.Error(err))
 	}
+	s.autocomplete.Add(ctx, movie)
 
 	return movie, nil
 }
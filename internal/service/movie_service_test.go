@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+func TestMovieCursorRoundTrip(t *testing.T) {
+	tests := []domain.MovieCursor{
+		{Sort: domain.SortTitle, Value: "Amélie & \"Friends\"", ID: uuid.New()},
+		{Sort: domain.SortYear, Value: "0", ID: uuid.New()},
+		{Sort: domain.SortImdbRating, Value: "-1", ID: uuid.New()},
+		{Sort: domain.SortRecent, Value: "2024-03-09T13:05:06.123456789Z", ID: uuid.New()},
+	}
+	for _, want := range tests {
+		t.Run(string(want.Sort), func(t *testing.T) {
+			token := encodeCursor(&want)
+			for _, r := range token {
+				if r == '+' || r == '/' || r == '=' {
+					t.Fatalf("token %q is not URL-safe", token)
+				}
+			}
+			var got domain.MovieCursor
+			if err := decodeCursor(token, &got); err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if got != want {
+				t.Fatalf("round trip = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	for _, token := range []string{"not base64!", encodeCursor("just a string")[:3], encodeCursor([]int{1})} {
+		var c domain.MovieCursor
+		if err := decodeCursor(token, &c); err == nil {
+			t.Errorf("decodeCursor(%q) succeeded, want error", token)
+		}
+	}
+}
+
+type hiddenRepo struct {
+	repository.MovieRepository
+	hidden map[string]bool
+}
+
+func (r hiddenRepo) ListHiddenImdbIDs(_ context.Context, ids []string) ([]string, error) {
+	var hidden []string
+	for _, id := range ids {
+		if r.hidden[id] {
+			hidden = append(hidden, id)
+		}
+	}
+	return hidden, nil
+}
+
+func TestDropHiddenKeepsProviderTotals(t *testing.T) {
+	s := &MovieService{movieRepo: hiddenRepo{hidden: map[string]bool{"tt2": true}}, logger: zap.NewNop()}
+	result := &domain.MovieSearchResponse{
+		Results:      []domain.SearchResult{{ImdbID: "tt1"}, {ImdbID: "tt2"}, {ImdbID: "tt3"}},
+		TotalResults: 25,
+		TotalPages:   3,
+		Page:         3,
+	}
+
+	got, err := s.dropHidden(context.Background(), result)
+	if err != nil {
+		t.Fatalf("dropHidden: %v", err)
+	}
+	var ids []string
+	for _, r := range got.Results {
+		ids = append(ids, r.ImdbID)
+	}
+	if !reflect.DeepEqual(ids, []string{"tt1", "tt3"}) {
+		t.Errorf("results = %v, want [tt1 tt3]", ids)
+	}
+	if got.TotalResults != 25 || got.TotalPages != 3 {
+		t.Errorf("totals = %d results / %d pages, want the provider's 25 / 3", got.TotalResults, got.TotalPages)
+	}
+}
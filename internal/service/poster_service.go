@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // register decoders for upstream poster formats
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+
+	"github.com/HugoSmits86/nativewebp"
+	"go.uber.org/zap"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/namru/movie-recommend/internal/config"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+	"github.com/namru/movie-recommend/pkg/blobstore"
+	"github.com/namru/movie-recommend/pkg/httpclient"
+)
+
+// maxPosterBytes caps how much of an upstream poster is downloaded.
+const maxPosterBytes = 10 << 20
+
+// Encodings a poster variant can be requested in.
+const (
+	PosterFormatJPEG = "jpeg"
+	PosterFormatWebP = "webp"
+)
+
+// Poster is an encoded poster image ready to be served.
+type Poster struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+// PosterService fetches movie posters once, keeps them in a blob store and
+// serves resized JPEG or WebP variants. Concurrent requests for the same
+// poster, width and format share a single fetch/resize.
+type PosterService struct {
+	movieRepo repository.MovieRepository
+	store     blobstore.Store
+	client    *httpclient.Client
+	cfg       *config.PosterConfig
+	logger    *zap.Logger
+	flights   singleflight.Group
+}
+
+func NewPosterService(
+	movieRepo repository.MovieRepository,
+	store blobstore.Store,
+	client *httpclient.Client,
+	cfg *config.PosterConfig,
+	logger *zap.Logger,
+) *PosterService {
+	return &PosterService{
+		movieRepo: movieRepo,
+		store:     store,
+		client:    client,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+// Get returns the poster of a stored movie at the smallest configured width
+// that is at least width, encoded as format (JPEG when empty). Width 0 with
+// no format returns the original image; with a format, the original size
+// re-encoded. Hidden movies have no poster here.
+func (s *PosterService) Get(ctx context.Context, imdbID string, width int, format string) (*Poster, error) {
+	movie, err := s.movieRepo.GetByImdbID(ctx, imdbID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "movie not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to get movie for poster", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if movie.Hidden {
+		// The route is public; do not leak posters of curated-out titles.
+		return nil, appErr.New(404, "movie not found", appErr.ErrNotFound)
+	}
+	if movie.PosterURL == "" || movie.PosterURL == "N/A" {
+		return nil, appErr.New(404, "movie has no poster", appErr.ErrNotFound)
+	}
+
+	// Keys include a hash of the upstream URL so a poster that changes on
+	// metadata refresh is fetched again instead of served stale.
+	prefix := fmt.Sprintf("posters/%s/%s", imdbID, shortHash([]byte(movie.PosterURL)))
+	width = s.snapWidth(width)
+	if width > 0 && format == "" {
+		format = PosterFormatJPEG
+	}
+
+	v, err, _ := s.flights.Do(fmt.Sprintf("%s:%d:%s", prefix, width, format), func() (interface{}, error) {
+		// Detach from the first caller so its cancellation does not fail
+		// every request sharing this flight.
+		ctx := context.WithoutCancel(ctx)
+		if format == "" {
+			return s.original(ctx, prefix, movie.PosterURL)
+		}
+		return s.variant(ctx, prefix, movie.PosterURL, width, format)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Poster), nil
+}
+
+// snapWidth rounds width up to a configured size so arbitrary widths cannot
+// fill the store; widths above the largest size get the largest size.
+func (s *PosterService) snapWidth(width int) int {
+	if width <= 0 || len(s.cfg.Widths) == 0 {
+		return 0
+	}
+	for _, w := range s.cfg.Widths {
+		if width <= w {
+			return w
+		}
+	}
+	return s.cfg.Widths[len(s.cfg.Widths)-1]
+}
+
+func (s *PosterService) original(ctx context.Context, prefix, posterURL string) (*Poster, error) {
+	data, err := s.originalBytes(ctx, prefix, posterURL)
+	if err != nil {
+		return nil, err
+	}
+	return newPoster(data, http.DetectContentType(data)), nil
+}
+
+// variant returns the poster scaled to width (0 keeps the original size) and
+// encoded as format, rendering and storing it on first use.
+func (s *PosterService) variant(ctx context.Context, prefix, posterURL string, width int, format string) (*Poster, error) {
+	ext, contentType := "jpg", "image/jpeg"
+	if format == PosterFormatWebP {
+		ext, contentType = "webp", "image/webp"
+	}
+	key := fmt.Sprintf("%s/w%d.%s", prefix, width, ext)
+	data, err := s.store.Get(ctx, key)
+	if err == nil {
+		return newPoster(data, contentType), nil
+	}
+	if !errors.Is(err, blobstore.ErrNotFound) {
+		s.logger.Error("failed to read poster variant", zap.String("key", key), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	src, err := s.originalBytes(ctx, prefix, posterURL)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = resizeImage(src, width, format); err != nil {
+		s.logger.Warn("failed to resize poster", zap.String("key", key), zap.Error(err))
+		return nil, appErr.New(502, "poster image could not be decoded", appErr.ErrExternalAPI)
+	}
+	if err := s.store.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		s.logger.Warn("failed to store poster variant", zap.String("key", key), zap.Error(err))
+	}
+	return newPoster(data, contentType), nil
+}
+
+// originalBytes returns the upstream poster, downloading and storing it on
+// first use.
+func (s *PosterService) originalBytes(ctx context.Context, prefix, posterURL string) ([]byte, error) {
+	key := prefix + "/original"
+	data, err := s.store.Get(ctx, key)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, blobstore.ErrNotFound) {
+		s.logger.Error("failed to read poster", zap.String("key", key), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	v, err, _ := s.flights.Do(key, func() (interface{}, error) {
+		return s.download(ctx, posterURL)
+	})
+	if err != nil {
+		return nil, err
+	}
+	data = v.([]byte)
+
+	if err := s.store.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		s.logger.Warn("failed to store poster", zap.String("key", key), zap.Error(err))
+	}
+	return data, nil
+}
+
+func (s *PosterService) download(ctx context.Context, posterURL string) ([]byte, error) {
+	resp, err := s.client.Get(ctx, posterURL)
+	if err != nil {
+		s.logger.Error("poster download failed", zap.String("url", posterURL), zap.Error(err))
+		return nil, appErr.ErrExternalAPI
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, appErr.New(404, "poster not found upstream", appErr.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		s.logger.Error("poster download returned error",
+			zap.String("url", posterURL),
+			zap.Int("status", resp.StatusCode),
+		)
+		return nil, appErr.ErrExternalAPI
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPosterBytes+1))
+	if err != nil {
+		s.logger.Error("failed to read poster", zap.String("url", posterURL), zap.Error(err))
+		return nil, appErr.ErrExternalAPI
+	}
+	if len(data) > maxPosterBytes {
+		return nil, appErr.New(502, "upstream poster is too large", appErr.ErrExternalAPI)
+	}
+	return data, nil
+}
+
+// resizeImage scales src down to width (never up; 0 keeps the size) and
+// encodes it as format. WebP output is lossless, as no lossy pure-Go encoder
+// is available.
+func resizeImage(src []byte, width int, format string) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+
+	b := img.Bounds()
+	if width > 0 && width < b.Dx() {
+		height := b.Dy() * width / b.Dx()
+		if height < 1 {
+			height = 1
+		}
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, xdraw.Over, nil)
+		img = dst
+	}
+
+	var buf bytes.Buffer
+	if format == PosterFormatWebP {
+		err = nativewebp.Encode(&buf, img, nil)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newPoster(data []byte, contentType string) *Poster {
+	return &Poster{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        `"` + shortHash(data) + `"`,
+	}
+}
+
+func shortHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/namru/movie-recommend/internal/config"
+)
+
+func TestSnapWidth(t *testing.T) {
+	s := &PosterService{cfg: &config.PosterConfig{Widths: []int{150, 300, 600}}}
+	tests := []struct {
+		width int
+		want  int
+	}{
+		{0, 0},
+		{-5, 0},
+		{1, 150},
+		{150, 150},
+		{151, 300},
+		{300, 300},
+		{599, 600},
+		{4000, 600},
+	}
+	for _, tt := range tests {
+		if got := s.snapWidth(tt.width); got != tt.want {
+			t.Errorf("snapWidth(%d) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+
+	none := &PosterService{cfg: &config.PosterConfig{}}
+	if got := none.snapWidth(300); got != 0 {
+		t.Errorf("snapWidth with no widths = %d, want 0 (original)", got)
+	}
+}
+
+func TestResizeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 600))
+	for y := 0; y < 600; y++ {
+		for x := 0; x < 400; x++ {
+			src.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		width      int
+		format     string
+		wantFormat string
+		wantW      int
+		wantH      int
+	}{
+		{"jpeg downscale", 200, PosterFormatJPEG, "jpeg", 200, 300},
+		{"webp downscale", 200, PosterFormatWebP, "webp", 200, 300},
+		{"never upscales", 800, PosterFormatJPEG, "jpeg", 400, 600},
+		{"full size re-encode", 0, PosterFormatWebP, "webp", 400, 600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := resizeImage(buf.Bytes(), tt.width, tt.format)
+			if err != nil {
+				t.Fatalf("resizeImage: %v", err)
+			}
+			cfg, format, err := image.DecodeConfig(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("decode output: %v", err)
+			}
+			if format != tt.wantFormat {
+				t.Errorf("format = %s, want %s", format, tt.wantFormat)
+			}
+			if cfg.Width != tt.wantW || cfg.Height != tt.wantH {
+				t.Errorf("size = %dx%d, want %dx%d", cfg.Width, cfg.Height, tt.wantW, tt.wantH)
+			}
+		})
+	}
+
+	if _, err := resizeImage([]byte("not an image"), 200, PosterFormatJPEG); err == nil {
+		t.Error("resizeImage accepted undecodable input")
+	}
+}
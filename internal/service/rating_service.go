@@ -16,17 +16,20 @@ import (
 type RatingService struct {
 	ratingRepo   repository.RatingRepository
 	movieService *MovieService
+	statsService *StatsService
 	logger       *zap.Logger
 }
 
 func NewRatingService(
 	ratingRepo repository.RatingRepository,
 	movieService *MovieService,
+	statsService *StatsService,
 	logger *zap.Logger,
 ) *RatingService {
 	return &RatingService{
 		ratingRepo:   ratingRepo,
 		movieService: movieService,
+		statsService: statsService,
 		logger:       logger,
 	}
 }
@@ -57,13 +60,20 @@ func (s *RatingService) Create(ctx context.Context, userID uuid.UUID, req *domai
 		s.logger.Error("failed to create rating", zap.Error(err))
 		return nil, appErr.ErrInternal
 	}
+	s.statsService.Invalidate(ctx, movie.ID)
 
 	return rating, nil
 }
 
-// GetAll returns all ratings for the user.
-func (s *RatingService) GetAll(ctx context.Context, userID uuid.UUID) ([]domain.Rating, error) {
-	ratings, err := s.ratingRepo.GetByUserID(ctx, userID)
+// GetAll returns the user's ratings, optionally only those carrying every
+// tag in req.
+func (s *RatingService) GetAll(ctx context.Context, userID uuid.UUID, req domain.RatingListRequest) ([]domain.Rating, error) {
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := s.ratingRepo.GetByUserID(ctx, userID, tags)
 	if err != nil {
 		s.logger.Error("failed to get ratings", zap.Error(err))
 		return nil, appErr.ErrInternal
@@ -92,6 +102,7 @@ func (s *RatingService) Update(ctx context.Context, userID uuid.UUID, ratingID u
 		s.logger.Error("failed to update rating", zap.Error(err))
 		return nil, appErr.ErrInternal
 	}
+	s.statsService.Invalidate(ctx, rating.MovieID)
 
 	return rating, nil
 }
@@ -109,5 +120,9 @@ func (s *RatingService) Delete(ctx context.Context, userID uuid.UUID, ratingID u
 		return appErr.ErrForbidden
 	}
 
-	return s.ratingRepo.Delete(ctx, ratingID)
+	if err := s.ratingRepo.Delete(ctx, ratingID); err != nil {
+		return err
+	}
+	s.statsService.Invalidate(ctx, rating.MovieID)
+	return nil
 }
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"math"
+	"sort"
 	"strings"
+	"unicode"
 
 	"go.uber.org/zap"
 
@@ -12,6 +15,28 @@ import (
 	"github.com/namru/movie-recommend/internal/repository"
 )
 
+// Similarity factor weights; they sum to 1 so scores stay within 0-1.
+const (
+	weightGenres    = 0.30
+	weightDirector  = 0.15
+	weightCast      = 0.15
+	weightPlot      = 0.15
+	weightCoRatings = 0.25
+
+	// similarCandidatePool is how many overlap candidates are scored in full.
+	similarCandidatePool = 200
+)
+
+// plotStopwords are common words ignored when comparing plots.
+var plotStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "his": true, "her": true,
+	"their": true, "from": true, "into": true, "that": true, "this": true, "who": true,
+	"when": true, "while": true, "after": true, "before": true, "they": true, "them": true,
+	"are": true, "was": true, "has": true, "have": true, "but": true, "not": true,
+	"one": true, "two": true, "out": true, "its": true, "him": true, "she": true,
+	"all": true, "new": true, "about": true, "must": true, "only": true, "where": true,
+}
+
 type RecommendationService struct {
 	ratingRepo   repository.RatingRepository
 	movieRepo    repository.MovieRepository
@@ -93,18 +118,21 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, userID u
 	// Step 4: If local DB didn't yield enough, try OMDb search for each genre
 	if len(recommendations) < 10 {
 		for _, genre := range genres {
-			searchResult, err := s.movieService.Search(ctx, strings.TrimSpace(genre), 1)
+			searchResult, err := s.movieService.Search(ctx, domain.MovieSearchRequest{
+				Query: strings.TrimSpace(genre),
+				Page:  1,
+			})
 			if err != nil {
 				s.logger.Warn("omdb genre search failed", zap.String("genre", genre), zap.Error(err))
 				continue
 			}
 
-			for _, sr := range searchResult.Search {
+			for _, sr := range searchResult.Results {
 				movie, err := s.movieService.GetByImdbID(ctx, sr.ImdbID)
 				if err != nil {
 					continue
 				}
-				if ratedSet[movie.ID] {
+				if movie.Hidden || ratedSet[movie.ID] {
 					continue
 				}
 
@@ -120,3 +148,134 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, userID u
 
 	return recommendations, nil
 }
+
+// GetSimilar returns the stored movies most similar to imdbID. Candidates
+// share at least one genre, director, actor or liker with it; each is scored
+// on genre overlap, shared director and cast, plot wording and co-ratings,
+// and every non-zero factor is reported with its contribution.
+func (s *RecommendationService) GetSimilar(ctx context.Context, imdbID string, limit int) (*domain.SimilarMoviesResponse, error) {
+	if limit == 0 {
+		limit = 10
+	}
+
+	source, err := s.movieService.GetByImdbID(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	src, candidates, err := s.movieRepo.SimilarCandidates(ctx, source.ID, similarCandidatePool)
+	if err != nil {
+		s.logger.Error("failed to load similar candidates", zap.String("imdbID", imdbID), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	sourceTerms := plotTerms(source.Plot)
+	results := make([]domain.SimilarMovie, 0, len(candidates))
+	for i := range candidates {
+		if r := scoreSimilar(src, sourceTerms, &candidates[i]); r.Score > 0 {
+			results = append(results, r)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return &domain.SimilarMoviesResponse{ImdbID: source.ImdbID, Results: results}, nil
+}
+
+// scoreSimilar combines the weighted factors for one candidate.
+func scoreSimilar(src *domain.SimilaritySource, sourceTerms map[string]int, c *domain.SimilarityCandidate) domain.SimilarMovie {
+	result := domain.SimilarMovie{Movie: c.Movie, Factors: []domain.SimilarityFactor{}}
+	add := func(name string, weight, score float64, shared []string) {
+		if score <= 0 {
+			return
+		}
+		contribution := weight * score
+		result.Score += contribution
+		result.Factors = append(result.Factors, domain.SimilarityFactor{
+			Factor:       name,
+			Score:        round3(score),
+			Contribution: round3(contribution),
+			Shared:       shared,
+		})
+	}
+
+	// Jaccard overlap of the two genre sets.
+	if union := src.GenreCount + c.GenreCount - len(c.SharedGenres); union > 0 {
+		add("genres", weightGenres, float64(len(c.SharedGenres))/float64(union), c.SharedGenres)
+	}
+	if src.DirectorCount > 0 {
+		add("director", weightDirector, float64(len(c.SharedDirectors))/float64(src.DirectorCount), c.SharedDirectors)
+	}
+	if src.ActorCount > 0 {
+		add("cast", weightCast, float64(len(c.SharedActors))/float64(src.ActorCount), c.SharedActors)
+	}
+	plot, terms := plotSimilarity(sourceTerms, plotTerms(c.Movie.Plot))
+	add("plot", weightPlot, plot, terms)
+	// Cosine similarity of the two movies' sets of likers.
+	if src.LikeCount > 0 && c.LikeCount > 0 {
+		add("co_ratings", weightCoRatings, float64(c.CoLikes)/math.Sqrt(float64(src.LikeCount*c.LikeCount)), nil)
+	}
+
+	result.Score = round3(result.Score)
+	return result
+}
+
+// plotTerms counts the meaningful words of a plot summary.
+func plotTerms(plot string) map[string]int {
+	if plot == "" || plot == "N/A" {
+		return nil
+	}
+	terms := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(plot), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if len(w) < 3 || plotStopwords[w] {
+			continue
+		}
+		terms[w]++
+	}
+	return terms
+}
+
+// plotSimilarity returns the cosine similarity of two term-count vectors and
+// up to five shared terms, most frequent first.
+func plotSimilarity(a, b map[string]int) (float64, []string) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, nil
+	}
+	var dot, normA, normB float64
+	var shared []string
+	for term, n := range a {
+		normA += float64(n * n)
+		if m, ok := b[term]; ok {
+			dot += float64(n * m)
+			shared = append(shared, term)
+		}
+	}
+	for _, m := range b {
+		normB += float64(m * m)
+	}
+	if dot == 0 {
+		return 0, nil
+	}
+
+	sort.Slice(shared, func(i, j int) bool {
+		wi, wj := a[shared[i]]*b[shared[i]], a[shared[j]]*b[shared[j]]
+		if wi != wj {
+			return wi > wj
+		}
+		return shared[i] < shared[j]
+	})
+	if len(shared) > 5 {
+		shared = shared[:5]
+	}
+	return dot / math.Sqrt(normA*normB), shared
+}
+
+func round3(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+func TestScoreSimilar(t *testing.T) {
+	plot := "A computer hacker discovers that reality is a simulation run by machines."
+
+	tests := []struct {
+		name        string
+		src         domain.SimilaritySource
+		sourcePlot  string
+		cand        domain.SimilarityCandidate
+		wantScore   float64
+		wantFactors map[string]float64 // factor -> contribution
+	}{
+		{
+			name: "every factor",
+			src:  domain.SimilaritySource{GenreCount: 2, DirectorCount: 1, ActorCount: 4, LikeCount: 4},
+			cand: domain.SimilarityCandidate{
+				SharedGenres:    []string{"Action", "Sci-Fi"},
+				GenreCount:      2,
+				SharedDirectors: []string{"Lana Wachowski"},
+				SharedActors:    []string{"Keanu Reeves", "Carrie-Anne Moss"},
+				CoLikes:         3,
+				LikeCount:       9,
+			},
+			// genres 1.0*0.30 + director 1.0*0.15 + cast 0.5*0.15 + co-ratings 3/6*0.25
+			wantScore: 0.65,
+			wantFactors: map[string]float64{
+				"genres": 0.3, "director": 0.15, "cast": 0.075, "co_ratings": 0.125,
+			},
+		},
+		{
+			name:        "genre jaccard uses the union",
+			src:         domain.SimilaritySource{GenreCount: 3},
+			cand:        domain.SimilarityCandidate{SharedGenres: []string{"Drama"}, GenreCount: 2},
+			wantScore:   0.075, // 1 shared of 4 distinct genres
+			wantFactors: map[string]float64{"genres": 0.075},
+		},
+		{
+			name:        "identical plots",
+			sourcePlot:  plot,
+			cand:        domain.SimilarityCandidate{Movie: domain.Movie{Plot: plot}},
+			wantScore:   weightPlot,
+			wantFactors: map[string]float64{"plot": weightPlot},
+		},
+		{
+			name:        "missing source data does not divide by zero",
+			cand:        domain.SimilarityCandidate{SharedActors: []string{"Someone"}, CoLikes: 2, LikeCount: 5},
+			wantScore:   0,
+			wantFactors: map[string]float64{},
+		},
+		{
+			name:        "no overlap",
+			src:         domain.SimilaritySource{GenreCount: 2, DirectorCount: 1, ActorCount: 3, LikeCount: 10},
+			sourcePlot:  plot,
+			cand:        domain.SimilarityCandidate{GenreCount: 1, LikeCount: 4, Movie: domain.Movie{Plot: "N/A"}},
+			wantScore:   0,
+			wantFactors: map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreSimilar(&tt.src, plotTerms(tt.sourcePlot), &tt.cand)
+			if math.Abs(got.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("Score = %v, want %v", got.Score, tt.wantScore)
+			}
+			factors := make(map[string]float64)
+			for _, f := range got.Factors {
+				factors[f.Factor] = f.Contribution
+			}
+			if !reflect.DeepEqual(factors, tt.wantFactors) {
+				t.Errorf("factors = %v, want %v", factors, tt.wantFactors)
+			}
+			if got.Factors == nil {
+				t.Error("Factors is nil, want an empty slice for JSON")
+			}
+		})
+	}
+}
+
+func TestPlotSimilaritySharedTerms(t *testing.T) {
+	a := map[string]int{"heist": 3, "crew": 1, "vault": 2, "casino": 1}
+	b := map[string]int{"heist": 1, "vault": 1, "crew": 2, "bank": 4}
+
+	score, shared := plotSimilarity(a, b)
+	if score <= 0 || score >= 1 {
+		t.Fatalf("score = %v, want within (0, 1)", score)
+	}
+	// Ordered by a*b weight, ties alphabetically.
+	if want := []string{"heist", "crew", "vault"}; !reflect.DeepEqual(shared, want) {
+		t.Fatalf("shared = %v, want %v", shared, want)
+	}
+	if score, shared := plotSimilarity(a, nil); score != 0 || shared != nil {
+		t.Fatalf("empty side = %v, %v; want 0, nil", score, shared)
+	}
+}
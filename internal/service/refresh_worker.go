@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/config"
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/provider"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// refreshSaveAttempts bounds how often one refresh is re-applied when admin
+// changes keep landing between reading a movie and writing it back.
+const refreshSaveAttempts = 3
+
+var errRefreshConflict = errors.New("movie changed during refresh")
+
+// RefreshWorker periodically re-fetches stored movies whose metadata is older
+// than the configured age and writes back any fields that changed.
+type RefreshWorker struct {
+	movieRepo    repository.MovieRepository
+	cache        repository.CacheRepository
+	provider     provider.MovieProvider
+	autocomplete *AutocompleteService
+	cfg          *config.RefreshConfig
+	logger       *zap.Logger
+}
+
+func NewRefreshWorker(
+	movieRepo repository.MovieRepository,
+	cache repository.CacheRepository,
+	movieProvider provider.MovieProvider,
+	autocomplete *AutocompleteService,
+	cfg *config.RefreshConfig,
+	logger *zap.Logger,
+) *RefreshWorker {
+	return &RefreshWorker{
+		movieRepo:    movieRepo,
+		cache:        cache,
+		provider:     movieProvider,
+		autocomplete: autocomplete,
+		cfg:          cfg,
+		logger:       logger,
+	}
+}
+
+// Run refreshes one batch per interval until ctx is cancelled.
+func (w *RefreshWorker) Run(ctx context.Context) {
+	w.logger.Info("refresh worker started",
+		zap.Duration("interval", w.cfg.Interval),
+		zap.Duration("max_age", w.cfg.MaxAge),
+		zap.Int("batch_size", w.cfg.BatchSize),
+		zap.Int("daily_budget", w.cfg.DailyBudget),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.runBatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("refresh worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runBatch refreshes up to BatchSize stale movies, stopping early when the
+// daily request budget is spent or ctx is cancelled.
+func (w *RefreshWorker) runBatch(ctx context.Context) {
+	movies, err := w.movieRepo.ListStale(ctx, time.Now().Add(-w.cfg.MaxAge), w.cfg.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Error("failed to list stale movies", zap.Error(err))
+		}
+		return
+	}
+	if len(movies) == 0 {
+		return
+	}
+
+	var updated, unchanged, failed int
+	for i := range movies {
+		if ctx.Err() != nil {
+			break
+		}
+		ok, err := w.spendBudget(ctx)
+		if err != nil {
+			w.logger.Error("failed to check refresh budget", zap.Error(err))
+			break
+		}
+		if !ok {
+			w.logger.Info("daily refresh budget exhausted", zap.Int("budget", w.cfg.DailyBudget))
+			break
+		}
+
+		changes, err := w.refresh(ctx, &movies[i])
+		switch {
+		case err != nil:
+			failed++
+		case len(changes) > 0:
+			updated++
+		default:
+			unchanged++
+		}
+	}
+
+	w.logger.Info("refresh batch finished",
+		zap.Int("stale", len(movies)),
+		zap.Int("updated", updated),
+		zap.Int("unchanged", unchanged),
+		zap.Int("failed", failed),
+	)
+}
+
+// spendBudget reserves one provider request from today's budget.
+func (w *RefreshWorker) spendBudget(ctx context.Context) (bool, error) {
+	key := fmt.Sprintf("refresh:budget:%s", time.Now().UTC().Format("2006-01-02"))
+	used, err := w.cache.Incr(ctx, key, 24*time.Hour)
+	if err != nil {
+		return false, err
+	}
+	return used <= int64(w.cfg.DailyBudget), nil
+}
+
+// refresh re-fetches one movie and persists the fields that changed. It
+// returns the names of the changed fields.
+func (w *RefreshWorker) refresh(ctx context.Context, movie *domain.Movie) ([]string, error) {
+	log := w.logger.With(zap.String("imdb_id", movie.ImdbID))
+
+	detail, err := w.provider.GetByExternalID(ctx, movie.ImdbID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			// The provider no longer knows this ID; don't retry it every batch.
+			log.Warn("movie not found upstream, skipping")
+			_ = w.movieRepo.MarkRefreshed(ctx, movie.ID, time.Now())
+		} else if ctx.Err() == nil {
+			log.Error("failed to refresh movie", zap.Error(err))
+			// Move it to the back of the queue so one bad title cannot
+			// take the head of every batch and spend the budget daily.
+			if err := w.movieRepo.MarkRefreshAttempted(ctx, movie.ID, time.Now()); err != nil {
+				log.Error("failed to record refresh attempt", zap.Error(err))
+			}
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	upstream := movieFromDetail(detail)
+	var fresh *domain.Movie
+	var changes []string
+	for attempt := 1; ; attempt++ {
+		fresh = refreshedMovie(upstream, movie, now)
+		if changes = diffMovies(movie, fresh); len(changes) == 0 {
+			break
+		}
+
+		edit := domain.MovieEdit{Movie: fresh}
+		if movie.Genre != fresh.Genre || movie.Director != fresh.Director ||
+			movie.Writer != fresh.Writer || movie.Actors != fresh.Actors {
+			edit.SaveCredits = true
+			edit.Genres, edit.Credits = creditsFromMovie(fresh)
+		}
+		saved, err := w.movieRepo.SaveRefresh(ctx, edit, movie.UpdatedAt)
+		if err != nil {
+			log.Error("failed to update movie", zap.Error(err))
+			return nil, err
+		}
+		if saved {
+			break
+		}
+		if attempt == refreshSaveAttempts {
+			log.Warn("movie keeps changing during refresh, retrying next batch")
+			return nil, errRefreshConflict
+		}
+		// An admin changed the movie since it was read: apply the refresh
+		// to what is stored now, honouring any new locks.
+		if movie, err = w.movieRepo.GetByID(ctx, movie.ID); err != nil {
+			if !errors.Is(err, appErr.ErrNotFound) {
+				log.Error("failed to reload movie", zap.Error(err))
+			}
+			return nil, err
+		}
+	}
+
+	if len(changes) > 0 {
+		// The cached provider payload predates the refresh.
+		if err := w.cache.Delete(ctx, movieCacheKey(movie.ImdbID)); err != nil {
+			log.Warn("failed to invalidate movie cache", zap.Error(err))
+		}
+		if !movie.Hidden && suggestionChanged(movie, fresh) {
+			w.autocomplete.Remove(ctx, movie)
+			w.autocomplete.Add(ctx, fresh)
+		}
+		log.Info("movie metadata refreshed", zap.Strings("changed", changes))
+	}
+
+	if err := w.movieRepo.MarkRefreshed(ctx, movie.ID, now); err != nil {
+		log.Error("failed to mark movie refreshed", zap.Error(err))
+		return changes, err
+	}
+	return changes, nil
+}
+
+// refreshedMovie returns stored with its metadata replaced by upstream's,
+// except for the fields an admin locked.
+func refreshedMovie(upstream, stored *domain.Movie, now time.Time) *domain.Movie {
+	fresh := *upstream
+	keepLockedFields(&fresh, stored)
+	fresh.ID = stored.ID
+	fresh.UpdatedAt = now
+	return &fresh
+}
+
+// suggestionChanged reports whether any field shown in autocomplete differs.
+func suggestionChanged(old, fresh *domain.Movie) bool {
+	return titleSuggestion(old) != titleSuggestion(fresh)
+}
+
+type fieldValue struct {
+	name, value string
+}
+
+// movieFieldValues returns the refreshable metadata fields of m as strings.
+// The names are also the ones admins edit and lock.
+func movieFieldValues(m *domain.Movie) []fieldValue {
+	return []fieldValue{
+		{"title", m.Title},
+		{"year", m.Year},
+		{"genre", m.Genre},
+		{"director", m.Director},
+		{"actors", m.Actors},
+		{"plot", m.Plot},
+		{"poster_url", m.PosterURL},
+		{"imdb_rating", m.ImdbRating},
+		{"rated", m.Rated},
+		{"released", formatDate(m.Released)},
+		{"runtime_minutes", formatInt(m.RuntimeMinutes)},
+		{"writer", m.Writer},
+		{"language", m.Language},
+		{"country", m.Country},
+		{"awards", m.Awards},
+		{"type", m.Type},
+		{"total_seasons", formatInt(m.TotalSeasons)},
+	}
+}
+
+// diffMovies lists the metadata fields that differ between old and fresh.
+func diffMovies(old, fresh *domain.Movie) []string {
+	before, after := movieFieldValues(old), movieFieldValues(fresh)
+	var changed []string
+	for i := range before {
+		if before[i].value != after[i].value {
+			changed = append(changed, before[i].name)
+		}
+	}
+	return changed
+}
+
+// fieldChanges maps each metadata field that differs between old and fresh
+// to its before and after values.
+func fieldChanges(old, fresh *domain.Movie) map[string]domain.FieldChange {
+	before, after := movieFieldValues(old), movieFieldValues(fresh)
+	changes := make(map[string]domain.FieldChange)
+	for i := range before {
+		if before[i].value != after[i].value {
+			changes[before[i].name] = domain.FieldChange{Old: before[i].value, New: after[i].value}
+		}
+	}
+	return changes
+}
+
+// keepLockedFields copies old's value of every field locked by an admin onto
+// fresh, along with the typed columns derived from it.
+func keepLockedFields(fresh, old *domain.Movie) {
+	for _, field := range old.LockedFields {
+		switch field {
+		case "title":
+			fresh.Title = old.Title
+		case "year":
+			fresh.Year, fresh.ReleaseYear = old.Year, old.ReleaseYear
+		case "genre":
+			fresh.Genre = old.Genre
+		case "director":
+			fresh.Director = old.Director
+		case "actors":
+			fresh.Actors = old.Actors
+		case "plot":
+			fresh.Plot = old.Plot
+		case "poster_url":
+			fresh.PosterURL = old.PosterURL
+		case "imdb_rating":
+			fresh.ImdbRating, fresh.ImdbScore = old.ImdbRating, old.ImdbScore
+		case "rated":
+			fresh.Rated = old.Rated
+		case "released":
+			fresh.Released = old.Released
+		case "runtime_minutes":
+			fresh.RuntimeMinutes = old.RuntimeMinutes
+		case "writer":
+			fresh.Writer = old.Writer
+		case "language":
+			fresh.Language = old.Language
+		case "country":
+			fresh.Country = old.Country
+		case "awards":
+			fresh.Awards = old.Awards
+		case "type":
+			fresh.Type = old.Type
+		case "total_seasons":
+			fresh.TotalSeasons = old.TotalSeasons
+		}
+	}
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return fmt.Sprint(*n)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// refreshRepo is an in-memory MovieRepository holding one movie. beforeSave,
+// when set, runs at the start of each SaveRefresh to simulate an admin change
+// landing between the worker's read and its write.
+type refreshRepo struct {
+	repository.MovieRepository
+
+	movie      domain.Movie
+	beforeSave func(call int, m *domain.Movie)
+	saves      int
+	saved      []domain.MovieEdit
+	refreshed  bool
+}
+
+func (r *refreshRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Movie, error) {
+	if id != r.movie.ID {
+		return nil, appErr.ErrNotFound
+	}
+	m := r.movie
+	return &m, nil
+}
+
+func (r *refreshRepo) SaveRefresh(_ context.Context, edit domain.MovieEdit, seen time.Time) (bool, error) {
+	r.saves++
+	if r.beforeSave != nil {
+		r.beforeSave(r.saves, &r.movie)
+	}
+	if !r.movie.UpdatedAt.Equal(seen) {
+		return false, nil
+	}
+	locks, hidden := r.movie.LockedFields, r.movie.Hidden
+	r.movie = *edit.Movie
+	r.movie.LockedFields, r.movie.Hidden = locks, hidden
+	r.saved = append(r.saved, edit)
+	return true, nil
+}
+
+func (r *refreshRepo) MarkRefreshed(context.Context, uuid.UUID, time.Time) error {
+	r.refreshed = true
+	return nil
+}
+
+type refreshProvider struct {
+	detail domain.OMDbMovieDetail
+}
+
+func (p *refreshProvider) Name() string { return "fake" }
+func (p *refreshProvider) Search(context.Context, domain.MovieSearchRequest) (*domain.MovieSearchResponse, error) {
+	return nil, errors.New("not implemented")
+}
+func (p *refreshProvider) GetByID(ctx context.Context, id string) (*domain.OMDbMovieDetail, error) {
+	return p.GetByExternalID(ctx, id)
+}
+func (p *refreshProvider) GetByExternalID(context.Context, string) (*domain.OMDbMovieDetail, error) {
+	d := p.detail
+	return &d, nil
+}
+
+type refreshCache struct {
+	repository.CacheRepository
+	deleted []string
+}
+
+func (c *refreshCache) Delete(_ context.Context, key string) error {
+	c.deleted = append(c.deleted, key)
+	return nil
+}
+
+type refreshSuggestions struct {
+	repository.SuggestionRepository
+}
+
+func (refreshSuggestions) Index(context.Context, []domain.SuggestionEntry) error { return nil }
+func (refreshSuggestions) Remove(context.Context, string, []string) error        { return nil }
+
+func newRefreshTest(stored domain.Movie, upstream domain.OMDbMovieDetail) (*RefreshWorker, *refreshRepo, *refreshCache) {
+	repo := &refreshRepo{movie: stored}
+	cache := &refreshCache{}
+	logger := zap.NewNop()
+	autocomplete := NewAutocompleteService(refreshSuggestions{}, repo, cache, logger)
+	return NewRefreshWorker(repo, cache, &refreshProvider{detail: upstream}, autocomplete, nil, logger), repo, cache
+}
+
+func storedMovie() domain.Movie {
+	return domain.Movie{
+		ID:        uuid.New(),
+		ImdbID:    "tt0133093",
+		Title:     "The Matrix",
+		Year:      "1999",
+		Plot:      "Old plot.",
+		Genre:     "Action",
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func upstreamDetail() domain.OMDbMovieDetail {
+	return domain.OMDbMovieDetail{
+		ImdbID: "tt0133093",
+		Title:  "The Matrix (Remastered)",
+		Year:   "1999",
+		Plot:   "New plot.",
+		Genre:  "Action, Sci-Fi",
+	}
+}
+
+func TestRefreshKeepsAdminEditMadeDuringRefresh(t *testing.T) {
+	stored := storedMovie()
+	w, repo, cache := newRefreshTest(stored, upstreamDetail())
+	repo.beforeSave = func(call int, m *domain.Movie) {
+		if call == 1 {
+			// The admin renames and locks the title while the worker waits
+			// on the provider.
+			m.Title = "The Matrix (Admin)"
+			m.LockedFields = []string{"title"}
+			m.UpdatedAt = m.UpdatedAt.Add(time.Minute)
+		}
+	}
+
+	changes, err := w.refresh(context.Background(), &stored)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if repo.saves != 2 || len(repo.saved) != 1 {
+		t.Fatalf("saves = %d (%d written), want a rejected save then one write", repo.saves, len(repo.saved))
+	}
+	if got := repo.movie.Title; got != "The Matrix (Admin)" {
+		t.Errorf("title = %q, want the admin's locked title", got)
+	}
+	if got := repo.movie.Plot; got != "New plot." {
+		t.Errorf("plot = %q, want the refreshed plot", got)
+	}
+	if !repo.saved[0].SaveCredits || !reflect.DeepEqual(repo.saved[0].Genres, []string{"Action", "Sci-Fi"}) {
+		t.Errorf("credits not saved with the refresh: %+v", repo.saved[0])
+	}
+	if !reflect.DeepEqual(changes, []string{"genre", "plot"}) {
+		t.Errorf("changes = %v, want [genre plot] (title is locked)", changes)
+	}
+	if !repo.refreshed {
+		t.Error("movie not marked refreshed")
+	}
+	if !reflect.DeepEqual(cache.deleted, []string{movieCacheKey(stored.ImdbID)}) {
+		t.Errorf("cache deletes = %v", cache.deleted)
+	}
+}
+
+func TestRefreshGivesUpWhenMovieKeepsChanging(t *testing.T) {
+	stored := storedMovie()
+	w, repo, _ := newRefreshTest(stored, upstreamDetail())
+	repo.beforeSave = func(_ int, m *domain.Movie) {
+		m.UpdatedAt = m.UpdatedAt.Add(time.Second)
+	}
+
+	if _, err := w.refresh(context.Background(), &stored); !errors.Is(err, errRefreshConflict) {
+		t.Fatalf("err = %v, want errRefreshConflict", err)
+	}
+	if repo.saves != refreshSaveAttempts || len(repo.saved) != 0 {
+		t.Fatalf("saves = %d (%d written), want %d rejected", repo.saves, len(repo.saved), refreshSaveAttempts)
+	}
+	if repo.refreshed {
+		t.Error("movie marked refreshed although nothing was written; it must be retried")
+	}
+}
+
+func TestRefreshUnchangedWritesNothing(t *testing.T) {
+	stored := storedMovie()
+	detail := upstreamDetail()
+	detail.Title, detail.Plot, detail.Genre = stored.Title, stored.Plot, stored.Genre
+	w, repo, _ := newRefreshTest(stored, detail)
+
+	changes, err := w.refresh(context.Background(), &stored)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("refresh = %v, %v; want no changes", changes, err)
+	}
+	if repo.saves != 0 || !repo.refreshed {
+		t.Fatalf("saves = %d, refreshed = %v; want 0 and true", repo.saves, repo.refreshed)
+	}
+}
+
+func TestKeepLockedFields(t *testing.T) {
+	year, score, runtime := 1999, 8.7, 136
+	old := &domain.Movie{
+		Title: "Old", Year: "1999", ReleaseYear: &year, ImdbRating: "8.7", ImdbScore: &score,
+		RuntimeMinutes: &runtime, Plot: "Old plot.",
+	}
+	newYear, newScore := 2000, 9.1
+	upstream := func() *domain.Movie {
+		return &domain.Movie{
+			Title: "New", Year: "2000", ReleaseYear: &newYear, ImdbRating: "9.1", ImdbScore: &newScore,
+			Plot: "New plot.",
+		}
+	}
+
+	tests := []struct {
+		name  string
+		locks []string
+		check func(t *testing.T, m *domain.Movie)
+	}{
+		{"no locks takes upstream", nil, func(t *testing.T, m *domain.Movie) {
+			if m.Title != "New" || m.Plot != "New plot." || m.RuntimeMinutes != nil {
+				t.Errorf("got %+v", m)
+			}
+		}},
+		{"year keeps the derived release year", []string{"year"}, func(t *testing.T, m *domain.Movie) {
+			if m.Year != "1999" || m.ReleaseYear == nil || *m.ReleaseYear != 1999 {
+				t.Errorf("year = %q / %v", m.Year, m.ReleaseYear)
+			}
+			if m.Title != "New" {
+				t.Errorf("unlocked title = %q", m.Title)
+			}
+		}},
+		{"imdb rating keeps the derived score", []string{"imdb_rating"}, func(t *testing.T, m *domain.Movie) {
+			if m.ImdbRating != "8.7" || m.ImdbScore == nil || *m.ImdbScore != 8.7 {
+				t.Errorf("rating = %q / %v", m.ImdbRating, m.ImdbScore)
+			}
+		}},
+		{"locked field cleared upstream stays", []string{"runtime_minutes", "plot"}, func(t *testing.T, m *domain.Movie) {
+			if m.RuntimeMinutes == nil || *m.RuntimeMinutes != 136 || m.Plot != "Old plot." {
+				t.Errorf("runtime = %v, plot = %q", m.RuntimeMinutes, m.Plot)
+			}
+		}},
+		{"unknown lock is ignored", []string{"no_such_field"}, func(t *testing.T, m *domain.Movie) {
+			if m.Title != "New" {
+				t.Errorf("title = %q", m.Title)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := *old
+			stored.LockedFields = tt.locks
+			fresh := upstream()
+			keepLockedFields(fresh, &stored)
+			tt.check(t, fresh)
+		})
+	}
+}
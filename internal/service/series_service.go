@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// SeriesService manages series episodes and per-episode watch progress for
+// series on a user's watchlist.
+type SeriesService struct {
+	episodeRepo   repository.EpisodeRepository
+	watchlistRepo repository.WatchlistRepository
+	movieService  *MovieService
+	statsService  *StatsService
+	history       *HistoryService
+	logger        *zap.Logger
+}
+
+func NewSeriesService(
+	episodeRepo repository.EpisodeRepository,
+	watchlistRepo repository.WatchlistRepository,
+	movieService *MovieService,
+	statsService *StatsService,
+	history *HistoryService,
+	logger *zap.Logger,
+) *SeriesService {
+	return &SeriesService{
+		episodeRepo:   episodeRepo,
+		watchlistRepo: watchlistRepo,
+		movieService:  movieService,
+		statsService:  statsService,
+		history:       history,
+		logger:        logger,
+	}
+}
+
+const (
+	// seasonResyncAfter is how long a season that may still be airing is
+	// served from storage before it is fetched again.
+	seasonResyncAfter = 24 * time.Hour
+	// seasonAiringWindow: a season whose newest episode aired within this
+	// window, or that has undated or future episodes, may still change.
+	seasonAiringWindow = 60 * 24 * time.Hour
+)
+
+var errNotSeries = appErr.New(400, "title is not a series", appErr.ErrBadRequest)
+
+// GetSeason returns one season of a series, fetching it from the provider the
+// first time it is requested and again daily while it may still be airing.
+func (s *SeriesService) GetSeason(ctx context.Context, imdbID string, number int) (*domain.Season, error) {
+	series, err := s.movieService.GetByImdbID(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if series.Type != "series" {
+		return nil, errNotSeries
+	}
+
+	season, err := s.loadSeason(ctx, series.ID, number)
+	if err != nil {
+		return nil, err
+	}
+	if s.needsSync(ctx, series, number, season.Episodes) {
+		if _, err := s.syncSeason(ctx, series, number); err != nil {
+			if len(season.Episodes) > 0 {
+				// Serve what is stored; the next request retries.
+				s.logger.Warn("failed to re-sync season", zap.String("imdb_id", series.ImdbID), zap.Int("season", number), zap.Error(err))
+				return season, nil
+			}
+			return nil, err
+		}
+		if season, err = s.loadSeason(ctx, series.ID, number); err != nil {
+			return nil, err
+		}
+	}
+	return season, nil
+}
+
+// GetProgress reports which episodes of a watchlisted series the user has watched.
+func (s *SeriesService) GetProgress(ctx context.Context, userID, entryID uuid.UUID) (*domain.SeriesProgress, error) {
+	entry, err := s.seriesEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes, err := s.ensureEpisodes(ctx, entry.Movie)
+	if err != nil {
+		return nil, err
+	}
+	watched, err := s.episodeRepo.GetWatched(ctx, entry.ID)
+	if err != nil {
+		s.logger.Error("failed to get episode progress", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	return buildProgress(entry, episodes, watched), nil
+}
+
+// SetEpisodeWatched marks or unmarks an episode as watched and moves the
+// watchlist status along: the first watched episode makes the entry
+// "watching", and watching every aired episode makes it "watched".
+func (s *SeriesService) SetEpisodeWatched(ctx context.Context, userID, entryID uuid.UUID, season, number int, watched bool) (*domain.SeriesProgress, error) {
+	entry, err := s.seriesEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	episodes, err := s.ensureEpisodes(ctx, entry.Movie)
+	if err != nil {
+		return nil, err
+	}
+	episode := findEpisode(episodes, season, number)
+	if episode == nil {
+		return nil, appErr.New(404, "episode "+domain.EpisodeCode(season, number)+" not found", appErr.ErrNotFound)
+	}
+
+	if watched {
+		err = s.episodeRepo.MarkWatched(ctx, entry.ID, episode.ID, time.Now())
+	} else {
+		err = s.episodeRepo.UnmarkWatched(ctx, entry.ID, episode.ID)
+	}
+	if err != nil {
+		s.logger.Error("failed to update episode progress", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	watchedEpisodes, err := s.episodeRepo.GetWatched(ctx, entry.ID)
+	if err != nil {
+		s.logger.Error("failed to get episode progress", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	progress := buildProgress(entry, episodes, watchedEpisodes)
+
+	if status := nextStatus(entry.Status, progress); status != entry.Status {
+		if err := s.watchlistRepo.Update(ctx, entry.ID, status); err != nil {
+			s.logger.Error("failed to update watchlist status", zap.Error(err))
+			return nil, appErr.ErrInternal
+		}
+		s.statsService.Invalidate(ctx, entry.MovieID)
+		if status == domain.StatusWatched {
+			s.history.LogWatched(ctx, entry)
+		}
+		s.logger.Info("series watchlist status changed",
+			zap.String("entry_id", entry.ID.String()),
+			zap.String("from", string(entry.Status)),
+			zap.String("to", string(status)),
+		)
+		progress.Status = status
+	}
+	return progress, nil
+}
+
+// seriesEntry loads a watchlist entry owned by userID whose title is a series.
+func (s *SeriesService) seriesEntry(ctx context.Context, userID, entryID uuid.UUID) (*domain.Watchlist, error) {
+	entry, err := s.watchlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.ErrNotFound
+		}
+		s.logger.Error("failed to get watchlist entry", zap.String("entry_id", entryID.String()), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if entry.UserID != userID {
+		return nil, appErr.ErrForbidden
+	}
+	if entry.Movie == nil || entry.Movie.Type != "series" {
+		return nil, errNotSeries
+	}
+	return entry, nil
+}
+
+// ensureEpisodes returns all episodes of a series, fetching any seasons that
+// have not been stored yet and re-fetching those that may still be airing.
+func (s *SeriesService) ensureEpisodes(ctx context.Context, series *domain.Movie) ([]domain.Episode, error) {
+	episodes, err := s.episodeRepo.GetBySeries(ctx, series.ID)
+	if err != nil {
+		s.logger.Error("failed to get episodes", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	have := make(map[int]bool)
+	bySeason := make(map[int][]domain.Episode)
+	for _, e := range episodes {
+		have[e.Season] = true
+		bySeason[e.Season] = append(bySeason[e.Season], e)
+	}
+
+	total := 0
+	if series.TotalSeasons != nil {
+		total = *series.TotalSeasons
+	}
+	synced := false
+	if total == 0 && !have[1] {
+		// Older rows lack total_seasons; season 1's response carries it.
+		if total, err = s.syncSeason(ctx, series, 1); err != nil {
+			return nil, err
+		}
+		synced = true
+	}
+	first := 1
+	if synced {
+		first = 2 // season 1 was just fetched above
+	}
+	for n := first; n <= total; n++ {
+		if have[n] && !s.needsSync(ctx, series, n, bySeason[n]) {
+			continue
+		}
+		if _, err := s.syncSeason(ctx, series, n); err != nil {
+			if errors.Is(err, appErr.ErrNotFound) {
+				s.logger.Warn("season not available", zap.String("imdb_id", series.ImdbID), zap.Int("season", n))
+				continue
+			}
+			if have[n] {
+				// A failed re-sync still leaves the stored episodes usable.
+				s.logger.Warn("failed to re-sync season", zap.String("imdb_id", series.ImdbID), zap.Int("season", n), zap.Error(err))
+				continue
+			}
+			return nil, err
+		}
+		synced = true
+	}
+
+	if !synced {
+		return episodes, nil
+	}
+	if episodes, err = s.episodeRepo.GetBySeries(ctx, series.ID); err != nil {
+		s.logger.Error("failed to get episodes", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return episodes, nil
+}
+
+// syncSeason fetches a season from the provider and stores its episodes. It
+// returns the series' total season count as reported by the provider.
+func (s *SeriesService) syncSeason(ctx context.Context, series *domain.Movie, number int) (int, error) {
+	resp, err := s.movieService.fetchSeason(ctx, series.ImdbID, number)
+	if err != nil {
+		return 0, err
+	}
+
+	episodes := make([]domain.Episode, 0, len(resp.Episodes))
+	for _, e := range resp.Episodes {
+		n, err := strconv.Atoi(e.Episode)
+		if err != nil {
+			continue
+		}
+		episodes = append(episodes, domain.Episode{
+			ID:         uuid.New(),
+			SeriesID:   series.ID,
+			Season:     number,
+			Episode:    n,
+			ImdbID:     notAvailable(e.ImdbID),
+			Title:      e.Title,
+			Released:   parseEpisodeReleased(e.Released),
+			ImdbRating: e.ImdbRating,
+		})
+	}
+
+	if err := s.episodeRepo.UpsertSeason(ctx, series.ID, episodes); err != nil {
+		s.logger.Error("failed to save episodes", zap.Error(err))
+		return 0, appErr.ErrInternal
+	}
+	if err := s.movieService.cache.Set(ctx, seasonSyncKey(series.ID, number), time.Now().UTC().Format(time.RFC3339), seasonResyncAfter); err != nil {
+		s.logger.Warn("failed to record season sync", zap.Error(err))
+	}
+
+	total := 0
+	if t := parsePositive(resp.TotalSeasons); t != nil {
+		total = *t
+	}
+	return total, nil
+}
+
+// needsSync reports whether a season must be fetched: it has no stored
+// episodes, or it may still be airing and was not fetched in the last
+// seasonResyncAfter. Cache errors are logged and the stored copy is used.
+func (s *SeriesService) needsSync(ctx context.Context, series *domain.Movie, number int, episodes []domain.Episode) bool {
+	if len(episodes) == 0 {
+		return true
+	}
+	if !seasonAiring(episodes, time.Now()) {
+		return false
+	}
+	synced, err := s.movieService.cache.Get(ctx, seasonSyncKey(series.ID, number))
+	if err != nil {
+		s.logger.Warn("failed to check season sync", zap.Error(err))
+		return false
+	}
+	return synced == ""
+}
+
+// seasonAiring reports whether a stored season may still gain or change
+// episodes: one of them is undated, unreleased or released recently.
+func seasonAiring(episodes []domain.Episode, now time.Time) bool {
+	cutoff := now.Add(-seasonAiringWindow)
+	for _, e := range episodes {
+		if e.Released == nil || e.Released.After(cutoff) {
+			return true
+		}
+	}
+	return false
+}
+
+func seasonSyncKey(seriesID uuid.UUID, number int) string {
+	return "series:season:synced:" + seriesID.String() + ":" + strconv.Itoa(number)
+}
+
+func (s *SeriesService) loadSeason(ctx context.Context, seriesID uuid.UUID, number int) (*domain.Season, error) {
+	episodes, err := s.episodeRepo.GetBySeries(ctx, seriesID)
+	if err != nil {
+		s.logger.Error("failed to get episodes", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	season := &domain.Season{Number: number, Episodes: []domain.Episode{}}
+	for _, e := range episodes {
+		if e.Season == number {
+			season.Episodes = append(season.Episodes, e)
+		}
+	}
+	return season, nil
+}
+
+// buildProgress groups episodes into seasons and summarizes what was watched.
+// Episodes with a future release date count towards neither the total nor
+// the watched count, so the two stay comparable.
+func buildProgress(entry *domain.Watchlist, episodes []domain.Episode, watched map[uuid.UUID]time.Time) *domain.SeriesProgress {
+	sort.Slice(episodes, func(i, j int) bool {
+		if episodes[i].Season != episodes[j].Season {
+			return episodes[i].Season < episodes[j].Season
+		}
+		return episodes[i].Episode < episodes[j].Episode
+	})
+
+	progress := &domain.SeriesProgress{
+		WatchlistID: entry.ID,
+		Status:      entry.Status,
+		Seasons:     []domain.Season{},
+	}
+	now := time.Now()
+	lastWatched := -1
+	for i := range episodes {
+		e := episodes[i]
+		aired := e.Released == nil || !e.Released.After(now)
+		if aired {
+			progress.Total++
+		}
+		if at, ok := watched[e.ID]; ok {
+			e.WatchedAt = &at
+			if aired {
+				progress.Watched++
+			}
+			lastWatched = i
+		}
+
+		if n := len(progress.Seasons); n == 0 || progress.Seasons[n-1].Number != e.Season {
+			progress.Seasons = append(progress.Seasons, domain.Season{Number: e.Season})
+		}
+		season := &progress.Seasons[len(progress.Seasons)-1]
+		season.Episodes = append(season.Episodes, e)
+	}
+
+	if lastWatched >= 0 {
+		progress.LastWatched = episodes[lastWatched].Code()
+	}
+	for i := lastWatched + 1; i < len(episodes); i++ {
+		if _, ok := watched[episodes[i].ID]; !ok {
+			next := episodes[i]
+			progress.NextEpisode = &next
+			break
+		}
+	}
+	return progress
+}
+
+// nextStatus derives the watchlist status implied by episode progress.
+func nextStatus(current domain.WatchlistStatus, p *domain.SeriesProgress) domain.WatchlistStatus {
+	switch {
+	case p.Total > 0 && p.Watched >= p.Total:
+		return domain.StatusWatched
+	case p.Watched > 0:
+		return domain.StatusWatching
+	case current == domain.StatusWatched:
+		return domain.StatusPlanToWatch
+	default:
+		return current
+	}
+}
+
+func findEpisode(episodes []domain.Episode, season, number int) *domain.Episode {
+	for i := range episodes {
+		if episodes[i].Season == season && episodes[i].Episode == number {
+			return &episodes[i]
+		}
+	}
+	return nil
+}
+
+// parseEpisodeReleased parses OMDb's "2008-01-20" episode release dates.
+func parseEpisodeReleased(s string) *time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/namru/movie-recommend/internal/domain"
+)
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		current domain.WatchlistStatus
+		watched int
+		total   int
+		want    domain.WatchlistStatus
+	}{
+		{"all aired watched", domain.StatusWatching, 10, 10, domain.StatusWatched},
+		{"started", domain.StatusPlanToWatch, 1, 10, domain.StatusWatching},
+		{"new episodes aired", domain.StatusWatched, 10, 12, domain.StatusWatching},
+		{"everything unmarked", domain.StatusWatched, 0, 10, domain.StatusPlanToWatch},
+		{"nothing watched keeps plan", domain.StatusPlanToWatch, 0, 10, domain.StatusPlanToWatch},
+		{"nothing aired", domain.StatusPlanToWatch, 0, 0, domain.StatusPlanToWatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextStatus(tt.current, &domain.SeriesProgress{Watched: tt.watched, Total: tt.total})
+			if got != tt.want {
+				t.Fatalf("nextStatus = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildProgress(t *testing.T) {
+	past := time.Now().Add(-48 * time.Hour)
+	future := time.Now().Add(48 * time.Hour)
+	ep := func(season, number int, released *time.Time) domain.Episode {
+		return domain.Episode{ID: uuid.New(), Season: season, Episode: number, Released: released}
+	}
+	// Deliberately out of order: buildProgress sorts.
+	episodes := []domain.Episode{
+		ep(2, 1, &past),
+		ep(1, 2, &past),
+		ep(1, 1, &past),
+		ep(2, 2, nil),
+		ep(2, 3, &future),
+	}
+	byCode := make(map[string]uuid.UUID)
+	for _, e := range episodes {
+		byCode[e.Code()] = e.ID
+	}
+
+	tests := []struct {
+		name        string
+		watched     []string
+		wantWatched int
+		wantLast    string
+		wantNext    string
+	}{
+		{"nothing watched", nil, 0, "", "S01E01"},
+		{"first season", []string{"S01E01", "S01E02"}, 2, "S01E02", "S02E01"},
+		{"skipped ahead", []string{"S02E01"}, 1, "S02E01", "S02E02"},
+		{"all aired", []string{"S01E01", "S01E02", "S02E01", "S02E02"}, 4, "S02E02", "S02E03"},
+		{"unaired not counted", []string{"S01E01", "S02E03"}, 1, "S02E03", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watched := make(map[uuid.UUID]time.Time)
+			for _, code := range tt.watched {
+				watched[byCode[code]] = past
+			}
+			input := append([]domain.Episode(nil), episodes...)
+			p := buildProgress(&domain.Watchlist{Status: domain.StatusWatching}, input, watched)
+
+			if p.Total != 4 {
+				t.Errorf("Total = %d, want 4 (unreleased episodes excluded)", p.Total)
+			}
+			if p.Watched != tt.wantWatched {
+				t.Errorf("Watched = %d, want %d", p.Watched, tt.wantWatched)
+			}
+			if p.Watched > p.Total {
+				t.Errorf("Watched %d exceeds Total %d", p.Watched, p.Total)
+			}
+			if p.LastWatched != tt.wantLast {
+				t.Errorf("LastWatched = %q, want %q", p.LastWatched, tt.wantLast)
+			}
+			next := ""
+			if p.NextEpisode != nil {
+				next = p.NextEpisode.Code()
+			}
+			if next != tt.wantNext {
+				t.Errorf("NextEpisode = %q, want %q", next, tt.wantNext)
+			}
+			if len(p.Seasons) != 2 || len(p.Seasons[0].Episodes) != 2 || len(p.Seasons[1].Episodes) != 3 {
+				t.Errorf("seasons not grouped: %+v", p.Seasons)
+			}
+		})
+	}
+}
+
+func TestSeasonAiring(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		released []*time.Time
+		want     bool
+	}{
+		{"ended long ago", []*time.Time{at(-400 * day), at(-393 * day)}, false},
+		{"finale last week", []*time.Time{at(-60 * day), at(-7 * day)}, true},
+		{"next episode scheduled", []*time.Time{at(-200 * day), at(7 * day)}, true},
+		{"undated episode", []*time.Time{at(-400 * day), nil}, true},
+		{"just outside window", []*time.Time{at(-seasonAiringWindow - day)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var episodes []domain.Episode
+			for i, r := range tt.released {
+				episodes = append(episodes, domain.Episode{Season: 1, Episode: i + 1, Released: r})
+			}
+			if got := seasonAiring(episodes, now); got != tt.want {
+				t.Fatalf("seasonAiring = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// StatsService computes community aggregates for movies from our own ratings
+// and watchlists, caching them until a rating or watchlist change invalidates them.
+type StatsService struct {
+	ratingRepo    repository.RatingRepository
+	watchlistRepo repository.WatchlistRepository
+	cache         repository.CacheRepository
+	ttl           time.Duration
+	logger        *zap.Logger
+}
+
+func NewStatsService(
+	ratingRepo repository.RatingRepository,
+	watchlistRepo repository.WatchlistRepository,
+	cache repository.CacheRepository,
+	ttl time.Duration,
+	logger *zap.Logger,
+) *StatsService {
+	return &StatsService{
+		ratingRepo:    ratingRepo,
+		watchlistRepo: watchlistRepo,
+		cache:         cache,
+		ttl:           ttl,
+		logger:        logger,
+	}
+}
+
+func statsCacheKey(movieID uuid.UUID) string {
+	return fmt.Sprintf("movie:stats:%s", movieID)
+}
+
+// GetStats returns the community aggregates for a movie (with Redis caching).
+func (s *StatsService) GetStats(ctx context.Context, movieID uuid.UUID) (*domain.MovieStats, error) {
+	cacheKey := statsCacheKey(movieID)
+	cached, err := s.cache.Get(ctx, cacheKey)
+	if err != nil {
+		s.logger.Warn("cache get error", zap.Error(err))
+	}
+	if cached != "" {
+		var stats domain.MovieStats
+		if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+			return &stats, nil
+		}
+	}
+
+	histogram, err := s.ratingRepo.GetScoreHistogram(ctx, movieID)
+	if err != nil {
+		s.logger.Error("failed to get score histogram", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	counts, err := s.watchlistRepo.CountByStatus(ctx, movieID)
+	if err != nil {
+		s.logger.Error("failed to count watchlist statuses", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	stats := &domain.MovieStats{
+		Histogram: make(map[int]int, 10),
+		Watchlist: map[domain.WatchlistStatus]int{
+			domain.StatusPlanToWatch: counts[domain.StatusPlanToWatch],
+			domain.StatusWatching:    counts[domain.StatusWatching],
+			domain.StatusWatched:     counts[domain.StatusWatched],
+		},
+	}
+	total := 0
+	for score := 1; score <= 10; score++ {
+		n := histogram[score]
+		stats.Histogram[score] = n
+		stats.RatingCount += n
+		total += score * n
+	}
+	if stats.RatingCount > 0 {
+		avg := float64(total) / float64(stats.RatingCount)
+		stats.AverageScore = math.Round(avg*100) / 100
+	}
+
+	if data, err := json.Marshal(stats); err == nil {
+		if err := s.cache.Set(ctx, cacheKey, string(data), s.ttl); err != nil {
+			s.logger.Warn("cache set error", zap.Error(err))
+		}
+	}
+
+	return stats, nil
+}
+
+// Enrich wraps a movie with its community stats and the user's own rating and
+// watchlist status.
+func (s *StatsService) Enrich(ctx context.Context, userID uuid.UUID, movie *domain.Movie) (*domain.MovieDetail, error) {
+	stats, err := s.GetStats(ctx, movie.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.MovieDetail{Movie: movie, Community: *stats}
+
+	rating, err := s.ratingRepo.GetByUserAndMovie(ctx, userID, movie.ID)
+	switch {
+	case err == nil:
+		detail.UserRating = rating
+	case !errors.Is(err, appErr.ErrNotFound):
+		s.logger.Error("failed to get user rating", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	entry, err := s.watchlistRepo.GetByUserAndMovie(ctx, userID, movie.ID)
+	switch {
+	case err == nil:
+		detail.WatchlistStatus = &entry.Status
+	case !errors.Is(err, appErr.ErrNotFound):
+		s.logger.Error("failed to get user watchlist entry", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	return detail, nil
+}
+
+// Invalidate drops the cached aggregates for a movie.
+func (s *StatsService) Invalidate(ctx context.Context, movieID uuid.UUID) {
+	if err := s.cache.Delete(ctx, statsCacheKey(movieID)); err != nil {
+		s.logger.Warn("failed to invalidate movie stats", zap.String("movieID", movieID.String()), zap.Error(err))
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+// TagService manages a user's personal tags on watchlist entries and ratings.
+// Tags are created the first time they are used and live until deleted, so
+// renaming, merging and counts work across both kinds of item.
+type TagService struct {
+	tagRepo       repository.TagRepository
+	watchlistRepo repository.WatchlistRepository
+	ratingRepo    repository.RatingRepository
+	logger        *zap.Logger
+}
+
+func NewTagService(
+	tagRepo repository.TagRepository,
+	watchlistRepo repository.WatchlistRepository,
+	ratingRepo repository.RatingRepository,
+	logger *zap.Logger,
+) *TagService {
+	return &TagService{
+		tagRepo:       tagRepo,
+		watchlistRepo: watchlistRepo,
+		ratingRepo:    ratingRepo,
+		logger:        logger,
+	}
+}
+
+// GetAll returns the user's tags with how many entries and ratings carry each.
+func (s *TagService) GetAll(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error) {
+	tags, err := s.tagRepo.ListByUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to list tags", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if tags == nil {
+		tags = []domain.Tag{}
+	}
+	return tags, nil
+}
+
+// SetWatchlistTags replaces the tags on one of the user's watchlist entries.
+func (s *TagService) SetWatchlistTags(ctx context.Context, userID, entryID uuid.UUID, names []string) (*domain.Watchlist, error) {
+	entry, err := s.watchlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, appErr.ErrInternal
+	}
+	if entry.UserID != userID {
+		return nil, appErr.ErrForbidden
+	}
+
+	names, err = normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.SetWatchlistTags(ctx, userID, entryID, names); err != nil {
+		s.logger.Error("failed to set watchlist tags", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	entry.Tags = names
+	return entry, nil
+}
+
+// SetRatingTags replaces the tags on one of the user's ratings.
+func (s *TagService) SetRatingTags(ctx context.Context, userID, ratingID uuid.UUID, names []string) (*domain.Rating, error) {
+	rating, err := s.ratingRepo.GetByID(ctx, ratingID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.ErrNotFound
+		}
+		return nil, appErr.ErrInternal
+	}
+	if rating.UserID != userID {
+		return nil, appErr.ErrForbidden
+	}
+
+	names, err = normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.SetRatingTags(ctx, userID, ratingID, names); err != nil {
+		s.logger.Error("failed to set rating tags", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	rating.Tags = names
+	return rating, nil
+}
+
+// Rename changes a tag's name everywhere it is used. Renaming onto another
+// existing tag is rejected; merge the two instead.
+func (s *TagService) Rename(ctx context.Context, userID, tagID uuid.UUID, name string) (*domain.Tag, error) {
+	tag, err := s.owned(ctx, userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	name = normalizeTag(name)
+	if name == "" {
+		return nil, appErr.New(400, "tag name must not be blank", appErr.ErrBadRequest)
+	}
+	if name == tag.Name {
+		return tag, nil
+	}
+
+	if err := s.tagRepo.Rename(ctx, tagID, name); err != nil {
+		if errors.Is(err, appErr.ErrAlreadyExists) {
+			return nil, appErr.New(409, "a tag with that name already exists; merge the tags instead", appErr.ErrAlreadyExists)
+		}
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "tag not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to rename tag", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	tag.Name = name
+	return tag, nil
+}
+
+// Merge moves every use of tagID onto intoID and deletes tagID, returning
+// the surviving tag with its new counts.
+func (s *TagService) Merge(ctx context.Context, userID, tagID, intoID uuid.UUID) (*domain.Tag, error) {
+	if tagID == intoID {
+		return nil, appErr.New(400, "cannot merge a tag into itself", appErr.ErrBadRequest)
+	}
+	if _, err := s.owned(ctx, userID, tagID); err != nil {
+		return nil, err
+	}
+	if _, err := s.owned(ctx, userID, intoID); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Merge(ctx, tagID, intoID); err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "tag not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to merge tags", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	return s.owned(ctx, userID, intoID)
+}
+
+// Delete removes a tag from every entry and rating carrying it.
+func (s *TagService) Delete(ctx context.Context, userID, tagID uuid.UUID) error {
+	if _, err := s.owned(ctx, userID, tagID); err != nil {
+		return err
+	}
+	if err := s.tagRepo.Delete(ctx, tagID); err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return appErr.New(404, "tag not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to delete tag", zap.Error(err))
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// owned loads a tag and verifies it belongs to userID.
+func (s *TagService) owned(ctx context.Context, userID, tagID uuid.UUID) (*domain.Tag, error) {
+	tag, err := s.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(404, "tag not found", appErr.ErrNotFound)
+		}
+		s.logger.Error("failed to get tag", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	if tag.UserID != userID {
+		return nil, appErr.ErrForbidden
+	}
+	return tag, nil
+}
+
+// normalizeTag trims a tag name, collapses inner whitespace and lower-cases
+// it, so tags differing only in case or spacing are the same tag.
+func normalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// normalizeTags normalizes and de-duplicates tag names, keeping their order.
+func normalizeTags(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, n := range names {
+		n = normalizeTag(n)
+		if n == "" {
+			return nil, appErr.New(400, "tag names must not be blank", appErr.ErrBadRequest)
+		}
+		if !seen[n] {
+			seen[n] = true
+			tags = append(tags, n)
+		}
+	}
+	return tags, nil
+}
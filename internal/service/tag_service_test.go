@@ -0,0 +1,34 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    []string
+		wantErr bool
+	}{
+		{"empty", nil, []string{}, false},
+		{"lower-cases and trims", []string{"  Sci-Fi "}, []string{"sci-fi"}, false},
+		{"collapses inner whitespace", []string{"date\t night", "Date  Night"}, []string{"date night"}, false},
+		{"keeps first-seen order", []string{"b", "A", "b", "c", "a"}, []string{"b", "a", "c"}, false},
+		{"keeps non-ASCII letters", []string{"Ämélie Vibes"}, []string{"ämélie vibes"}, false},
+		{"blank tag is rejected", []string{"fine", "   "}, nil, true},
+		{"empty tag is rejected", []string{""}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeTags(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("normalizeTags(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
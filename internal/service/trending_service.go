@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/config"
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+const (
+	rankingLockKey      = "movies:ranked:lock"
+	rankingUpdatedAtKey = "movies:ranked:updated_at"
+)
+
+// TrendingService materializes activity rankings into Redis sorted sets on
+// a schedule and serves them, so reads never touch the activity tables.
+type TrendingService struct {
+	activityRepo repository.ActivityRepository
+	movieRepo    repository.MovieRepository
+	cache        repository.CacheRepository
+	cfg          *config.TrendingConfig
+	logger       *zap.Logger
+}
+
+func NewTrendingService(
+	activityRepo repository.ActivityRepository,
+	movieRepo repository.MovieRepository,
+	cache repository.CacheRepository,
+	cfg *config.TrendingConfig,
+	logger *zap.Logger,
+) *TrendingService {
+	return &TrendingService{
+		activityRepo: activityRepo,
+		movieRepo:    movieRepo,
+		cache:        cache,
+		cfg:          cfg,
+		logger:       logger,
+	}
+}
+
+func rankingKey(kind domain.RankingKind, window domain.RankingWindow) string {
+	return fmt.Sprintf("movies:%s:%s", kind, window)
+}
+
+// rankingHalfLife returns the decay half-life for a ranking. Trending decays
+// within a quarter of the window so fresh activity dominates; popular decays
+// over the full window.
+func rankingHalfLife(kind domain.RankingKind, window time.Duration) time.Duration {
+	if kind == domain.RankingTrending {
+		return window / 4
+	}
+	return window
+}
+
+// Run recomputes all rankings once per interval until ctx is cancelled.
+func (s *TrendingService) Run(ctx context.Context) {
+	s.logger.Info("trending worker started",
+		zap.Duration("interval", s.cfg.Interval),
+		zap.Int("size", s.cfg.Size),
+	)
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.materialize(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("trending worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// materialize recomputes every ranking and window. A Redis lock ensures
+// only one instance does the work per interval.
+func (s *TrendingService) materialize(ctx context.Context) {
+	token := uuid.New().String()
+	ok, err := s.cache.AcquireLock(ctx, rankingLockKey, token, s.cfg.Interval/2)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to acquire ranking lock", zap.Error(err))
+		}
+		return
+	}
+	if !ok {
+		return
+	}
+	// The lock is left to expire rather than released, so instances whose
+	// tickers are slightly out of phase do not recompute right after us.
+
+	// Rankings outlive a few missed runs, then expire instead of going stale
+	// forever when the job stops.
+	ttl := 3 * s.cfg.Interval
+	now := time.Now()
+	var failed int
+	for _, kind := range domain.RankingKinds {
+		for window, length := range domain.RankingWindows {
+			if ctx.Err() != nil {
+				return
+			}
+			scores, err := s.activityRepo.ScoreMovies(ctx, now.Add(-length), rankingHalfLife(kind, length), s.cfg.Size)
+			if err == nil {
+				err = s.cache.ReplaceSortedSet(ctx, rankingKey(kind, window), scores, ttl)
+			}
+			if err != nil {
+				failed++
+				s.logger.Error("failed to materialize ranking",
+					zap.String("kind", string(kind)),
+					zap.String("window", string(window)),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	if failed == 0 {
+		if err := s.cache.Set(ctx, rankingUpdatedAtKey, now.UTC().Format(time.RFC3339), ttl); err != nil {
+			s.logger.Warn("failed to record ranking time", zap.Error(err))
+		}
+	}
+	s.logger.Info("rankings materialized", zap.Int("failed", failed), zap.Duration("took", time.Since(now)))
+}
+
+// List returns one materialized ranking. Before the first run, or when the
+// job is disabled, the list is empty.
+func (s *TrendingService) List(ctx context.Context, kind domain.RankingKind, req domain.RankedMoviesRequest) (*domain.RankedMoviesResponse, error) {
+	if req.Window == "" {
+		req.Window = domain.Window7d
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	scores, err := s.cache.TopSortedSet(ctx, rankingKey(kind, req.Window), req.Limit)
+	if err != nil {
+		s.logger.Error("failed to read ranking", zap.String("kind", string(kind)), zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+
+	resp := &domain.RankedMoviesResponse{
+		Kind:   kind,
+		Window: req.Window,
+		Movies: make([]domain.RankedMovie, 0, len(scores)),
+	}
+	if updated, err := s.cache.Get(ctx, rankingUpdatedAtKey); err == nil && updated != "" {
+		if t, err := time.Parse(time.RFC3339, updated); err == nil {
+			resp.UpdatedAt = &t
+		}
+	}
+	if len(scores) == 0 {
+		return resp, nil
+	}
+
+	imdbIDs := make([]string, len(scores))
+	for i, sc := range scores {
+		imdbIDs[i] = sc.ID
+	}
+	movies, err := s.movieRepo.GetByImdbIDs(ctx, imdbIDs)
+	if err != nil {
+		s.logger.Error("failed to load ranked movies", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	byID := make(map[string]domain.Movie, len(movies))
+	for _, m := range movies {
+		byID[m.ImdbID] = m
+	}
+
+	for _, sc := range scores {
+		m, ok := byID[sc.ID]
+		if !ok || m.Hidden {
+			continue // deleted or hidden since the last run
+		}
+		resp.Movies = append(resp.Movies, domain.RankedMovie{
+			Rank:  len(resp.Movies) + 1,
+			Score: sc.Score,
+			Movie: m,
+		})
+	}
+	return resp, nil
+}
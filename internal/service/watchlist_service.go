@@ -13,40 +13,69 @@ import (
 	"github.com/namru/movie-recommend/internal/repository"
 )
 
+// defaultWatchlistLimit is the page size when a listing request sets none.
+const defaultWatchlistLimit = 50
+
 type WatchlistService struct {
 	watchlistRepo repository.WatchlistRepository
+	listService   *ListService
 	movieService  *MovieService
+	statsService  *StatsService
+	history       *HistoryService
 	logger        *zap.Logger
 }
 
 func NewWatchlistService(
 	watchlistRepo repository.WatchlistRepository,
+	listService *ListService,
 	movieService *MovieService,
+	statsService *StatsService,
+	history *HistoryService,
 	logger *zap.Logger,
 ) *WatchlistService {
 	return &WatchlistService{
 		watchlistRepo: watchlistRepo,
+		listService:   listService,
 		movieService:  movieService,
+		statsService:  statsService,
+		history:       history,
 		logger:        logger,
 	}
 }
 
-// Add adds a movie to the user's watchlist. Fetches the movie from OMDb if not in DB.
+// Add adds a movie to the user's default list. Fetches the movie from OMDb if not in DB.
 func (s *WatchlistService) Add(ctx context.Context, userID uuid.UUID, req *domain.AddToWatchlistRequest) (*domain.Watchlist, error) {
+	list, err := s.listService.Default(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.add(ctx, list, req)
+}
+
+// AddToList adds a movie to one of the user's lists.
+func (s *WatchlistService) AddToList(ctx context.Context, userID, listID uuid.UUID, req *domain.AddToWatchlistRequest) (*domain.Watchlist, error) {
+	list, err := s.listService.Get(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	return s.add(ctx, list, req)
+}
+
+func (s *WatchlistService) add(ctx context.Context, list *domain.UserList, req *domain.AddToWatchlistRequest) (*domain.Watchlist, error) {
 	// Fetch or create the movie
 	movie, err := s.movieService.GetByImdbID(ctx, req.ImdbID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if already in watchlist
-	exists, err := s.watchlistRepo.Exists(ctx, userID, movie.ID)
+	// Check if already on the list
+	exists, err := s.watchlistRepo.Exists(ctx, list.ID, movie.ID)
 	if err != nil {
 		s.logger.Error("failed to check watchlist existence", zap.Error(err))
 		return nil, appErr.ErrInternal
 	}
 	if exists {
-		return nil, appErr.New(409, "movie already in watchlist", appErr.ErrAlreadyExists)
+		return nil, alreadyOnList(list)
 	}
 
 	status := req.Status
@@ -55,64 +84,204 @@ func (s *WatchlistService) Add(ctx context.Context, userID uuid.UUID, req *domai
 	}
 
 	entry := &domain.Watchlist{
-		ID:      uuid.New(),
-		UserID:  userID,
-		MovieID: movie.ID,
-		Status:  status,
-		AddedAt: time.Now(),
-		Movie:   movie,
+		ID:       uuid.New(),
+		UserID:   list.UserID,
+		ListID:   list.ID,
+		MovieID:  movie.ID,
+		Status:   status,
+		Priority: req.Priority,
+		Note:     req.Note,
+		AddedAt:  time.Now(),
+		Movie:    movie,
 	}
 
 	if err := s.watchlistRepo.Create(ctx, entry); err != nil {
 		if errors.Is(err, appErr.ErrAlreadyExists) {
-			return nil, appErr.New(409, "movie already in watchlist", appErr.ErrAlreadyExists)
+			return nil, alreadyOnList(list)
 		}
 		s.logger.Error("failed to add to watchlist", zap.Error(err))
 		return nil, appErr.ErrInternal
 	}
+	s.statsService.Invalidate(ctx, movie.ID)
 
 	return entry, nil
 }
 
-// GetAll returns all watchlist entries for the user.
-func (s *WatchlistService) GetAll(ctx context.Context, userID uuid.UUID) ([]domain.Watchlist, error) {
-	entries, err := s.watchlistRepo.GetByUserID(ctx, userID)
+// GetAll returns a page of the entries on the user's default list.
+func (s *WatchlistService) GetAll(ctx context.Context, userID uuid.UUID, req domain.WatchlistListRequest) (*domain.WatchlistPage, error) {
+	list, err := s.listService.Default(ctx, userID)
 	if err != nil {
+		return nil, err
+	}
+	return s.entries(ctx, list, req)
+}
+
+// GetList returns a page of the entries on one of the user's lists.
+func (s *WatchlistService) GetList(ctx context.Context, userID, listID uuid.UUID, req domain.WatchlistListRequest) (*domain.WatchlistPage, error) {
+	list, err := s.listService.Get(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	return s.entries(ctx, list, req)
+}
+
+// entries lists a list's entries matching the request filters, one keyset
+// page at a time. As with catalog browse, cursors are only valid for the
+// sort they were issued with.
+func (s *WatchlistService) entries(ctx context.Context, list *domain.UserList, req domain.WatchlistListRequest) (*domain.WatchlistPage, error) {
+	if req.Sort == "" {
+		req.Sort = domain.WatchlistSortPosition
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultWatchlistLimit
+	}
+	if req.YearFrom != 0 && req.YearTo != 0 && req.YearFrom > req.YearTo {
+		return nil, appErr.New(400, "year_from must not be after year_to", appErr.ErrBadRequest)
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	req.Tags = tags
+
+	q := domain.WatchlistQuery{ListID: list.ID, Filter: req, Limit: req.Limit + 1}
+	if req.Cursor != "" {
+		var cursor domain.WatchlistCursor
+		if err := decodeCursor(req.Cursor, &cursor); err != nil {
+			return nil, appErr.New(400, "invalid cursor", appErr.ErrBadRequest)
+		}
+		if cursor.Sort != req.Sort {
+			return nil, appErr.New(400, "cursor does not match sort", appErr.ErrBadRequest)
+		}
+		q.After = &cursor
+	}
+
+	entries, total, err := s.watchlistRepo.List(ctx, q)
+	if err != nil {
+		if errors.Is(err, appErr.ErrBadRequest) {
+			return nil, appErr.New(400, "invalid cursor", appErr.ErrBadRequest)
+		}
 		s.logger.Error("failed to get watchlist", zap.Error(err))
 		return nil, appErr.ErrInternal
 	}
-	return entries, nil
+
+	page := &domain.WatchlistPage{Entries: entries, Total: total}
+	if len(entries) > req.Limit {
+		page.Entries = entries[:req.Limit]
+		last := &page.Entries[req.Limit-1]
+		page.NextCursor = encodeCursor(&domain.WatchlistCursor{
+			Sort:   req.Sort,
+			Values: last.SortValues(req.Sort),
+			ID:     last.ID,
+		})
+	}
+	if page.Entries == nil {
+		page.Entries = []domain.Watchlist{}
+	}
+	return page, nil
 }
 
-// UpdateStatus updates a watchlist entry's status.
+// UpdateStatus updates a watchlist entry's status, priority and note. Only
+// the fields present in req are changed. Moving an entry to watched also logs
+// a viewing in the watch history.
 func (s *WatchlistService) UpdateStatus(ctx context.Context, userID uuid.UUID, entryID uuid.UUID, req *domain.UpdateWatchlistRequest) error {
-	// Verify ownership
-	entry, err := s.watchlistRepo.GetByID(ctx, entryID)
+	if req.Status == "" && req.Priority == nil && req.Note == nil {
+		return appErr.New(400, "nothing to update", appErr.ErrBadRequest)
+	}
+
+	entry, err := s.ownedEntry(ctx, userID, entryID)
 	if err != nil {
-		if errors.Is(err, appErr.ErrNotFound) {
-			return appErr.ErrNotFound
+		return err
+	}
+
+	if req.Priority != nil || req.Note != nil {
+		priority, note := entry.Priority, entry.Note
+		if req.Priority != nil {
+			priority = *req.Priority
+		}
+		if req.Note != nil {
+			note = *req.Note
+		}
+		if err := s.watchlistRepo.UpdateDetails(ctx, entryID, priority, note); err != nil {
+			if errors.Is(err, appErr.ErrNotFound) {
+				return appErr.ErrNotFound
+			}
+			s.logger.Error("failed to update watchlist entry", zap.Error(err))
+			return appErr.ErrInternal
 		}
-		return appErr.ErrInternal
 	}
-	if entry.UserID != userID {
-		return appErr.ErrForbidden
+
+	if req.Status != "" && req.Status != entry.Status {
+		if err := s.watchlistRepo.Update(ctx, entryID, req.Status); err != nil {
+			if errors.Is(err, appErr.ErrNotFound) {
+				return appErr.ErrNotFound
+			}
+			s.logger.Error("failed to update watchlist status", zap.Error(err))
+			return appErr.ErrInternal
+		}
+		s.statsService.Invalidate(ctx, entry.MovieID)
+		if req.Status == domain.StatusWatched {
+			s.history.LogWatched(ctx, entry)
+		}
 	}
+	return nil
+}
 
-	return s.watchlistRepo.Update(ctx, entryID, req.Status)
+// Move places an entry directly after another entry on the same list, or at
+// the top of the list when afterID is nil.
+func (s *WatchlistService) Move(ctx context.Context, userID, entryID uuid.UUID, afterID *uuid.UUID) (*domain.Watchlist, error) {
+	entry, err := s.ownedEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if afterID != nil && *afterID == entryID {
+		return nil, appErr.New(400, "cannot move an entry after itself", appErr.ErrBadRequest)
+	}
+
+	position, err := s.watchlistRepo.Move(ctx, entry.ListID, entryID, afterID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return nil, appErr.New(400, "after_id is not an entry on the same list", appErr.ErrBadRequest)
+		}
+		s.logger.Error("failed to move watchlist entry", zap.Error(err))
+		return nil, appErr.ErrInternal
+	}
+	entry.Position = position
+	return entry, nil
 }
 
 // Remove deletes a watchlist entry.
 func (s *WatchlistService) Remove(ctx context.Context, userID uuid.UUID, entryID uuid.UUID) error {
+	entry, err := s.ownedEntry(ctx, userID, entryID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.watchlistRepo.Delete(ctx, entryID); err != nil {
+		return err
+	}
+	s.statsService.Invalidate(ctx, entry.MovieID)
+	return nil
+}
+
+// ownedEntry loads a watchlist entry and verifies it belongs to userID.
+func (s *WatchlistService) ownedEntry(ctx context.Context, userID, entryID uuid.UUID) (*domain.Watchlist, error) {
 	entry, err := s.watchlistRepo.GetByID(ctx, entryID)
 	if err != nil {
 		if errors.Is(err, appErr.ErrNotFound) {
-			return appErr.ErrNotFound
+			return nil, appErr.ErrNotFound
 		}
-		return appErr.ErrInternal
+		return nil, appErr.ErrInternal
 	}
 	if entry.UserID != userID {
-		return appErr.ErrForbidden
+		return nil, appErr.ErrForbidden
 	}
+	return entry, nil
+}
 
-	return s.watchlistRepo.Delete(ctx, entryID)
+func alreadyOnList(list *domain.UserList) error {
+	if list.IsDefault {
+		return appErr.New(409, "movie already in watchlist", appErr.ErrAlreadyExists)
+	}
+	return appErr.New(409, "movie already in this list", appErr.ErrAlreadyExists)
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/namru/movie-recommend/internal/domain"
+	appErr "github.com/namru/movie-recommend/internal/errors"
+	"github.com/namru/movie-recommend/internal/repository"
+)
+
+func TestWatchlistCursorRoundTrip(t *testing.T) {
+	want := domain.WatchlistCursor{
+		Sort:   domain.WatchlistSortPriority,
+		Values: []string{"3", "-0.000000001"},
+		ID:     uuid.New(),
+	}
+	var got domain.WatchlistCursor
+	if err := decodeCursor(encodeCursor(&want), &got); err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+type updateRepo struct {
+	repository.WatchlistRepository
+	entry     domain.Watchlist
+	updateErr error
+}
+
+func (r *updateRepo) GetByID(context.Context, uuid.UUID) (*domain.Watchlist, error) {
+	e := r.entry
+	return &e, nil
+}
+
+func (r *updateRepo) UpdateDetails(context.Context, uuid.UUID, domain.WatchlistPriority, string) error {
+	return r.updateErr
+}
+
+func TestUpdateStatusMapsRepositoryErrors(t *testing.T) {
+	userID := uuid.New()
+	note := "rewatch"
+	tests := []struct {
+		name      string
+		updateErr error
+		want      error
+	}{
+		{"success", nil, nil},
+		{"deleted concurrently", appErr.ErrNotFound, appErr.ErrNotFound},
+		{"database error is not leaked", errors.New("pq: connection reset by peer"), appErr.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &updateRepo{entry: domain.Watchlist{ID: uuid.New(), UserID: userID}, updateErr: tt.updateErr}
+			s := &WatchlistService{watchlistRepo: repo, logger: zap.NewNop()}
+
+			err := s.UpdateStatus(context.Background(), userID, repo.entry.ID, &domain.UpdateWatchlistRequest{Note: &note})
+			if err != tt.want {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_movies_imdb_score;
+DROP INDEX IF EXISTS idx_movies_release_year;
+DROP INDEX IF EXISTS idx_movies_released;
+DROP INDEX IF EXISTS idx_movies_runtime_minutes;
+
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS imdb_score,
+    DROP COLUMN IF EXISTS release_year,
+    DROP COLUMN IF EXISTS type,
+    DROP COLUMN IF EXISTS awards,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS writer,
+    DROP COLUMN IF EXISTS runtime_minutes,
+    DROP COLUMN IF EXISTS released,
+    DROP COLUMN IF EXISTS rated;
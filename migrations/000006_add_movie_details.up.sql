@@ -0,0 +1,21 @@
+ALTER TABLE movies
+    ADD COLUMN rated           VARCHAR(20),
+    ADD COLUMN released        DATE,
+    ADD COLUMN runtime_minutes INTEGER,
+    ADD COLUMN writer          TEXT,
+    ADD COLUMN language        VARCHAR(255),
+    ADD COLUMN country         VARCHAR(255),
+    ADD COLUMN awards          TEXT,
+    ADD COLUMN type            VARCHAR(20),
+    ADD COLUMN release_year    INTEGER,
+    ADD COLUMN imdb_score      NUMERIC(3,1);
+
+-- Backfill the typed columns from the existing string columns
+UPDATE movies SET
+    release_year = substring(year FROM '^\d{4}')::INTEGER,
+    imdb_score   = CASE WHEN imdb_rating ~ '^\d+(\.\d+)?$' THEN imdb_rating::NUMERIC END;
+
+CREATE INDEX idx_movies_runtime_minutes ON movies(runtime_minutes);
+CREATE INDEX idx_movies_released ON movies(released);
+CREATE INDEX idx_movies_release_year ON movies(release_year);
+CREATE INDEX idx_movies_imdb_score ON movies(imdb_score);
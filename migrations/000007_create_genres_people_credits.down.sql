@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS movie_credits;
+DROP TABLE IF EXISTS people;
+DROP TABLE IF EXISTS movie_genres;
+DROP TABLE IF EXISTS genres;
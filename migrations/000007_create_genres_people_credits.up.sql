@@ -0,0 +1,66 @@
+CREATE TABLE genres (
+    id   SERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL
+);
+
+CREATE TABLE movie_genres (
+    movie_id UUID    NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    genre_id INTEGER NOT NULL REFERENCES genres(id) ON DELETE CASCADE,
+    PRIMARY KEY (movie_id, genre_id)
+);
+
+CREATE INDEX idx_movie_genres_genre_id ON movie_genres(genre_id);
+
+CREATE TABLE people (
+    id   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE movie_credits (
+    movie_id      UUID        NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    person_id     UUID        NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+    role          VARCHAR(20) NOT NULL,
+    billing_order INTEGER     NOT NULL DEFAULT 0,
+    PRIMARY KEY (movie_id, person_id, role),
+    CONSTRAINT chk_movie_credits_role CHECK (role IN ('director', 'writer', 'actor'))
+);
+
+CREATE INDEX idx_movie_credits_person_id ON movie_credits(person_id);
+
+-- Backfill from the comma-separated movie columns
+INSERT INTO genres (name)
+SELECT DISTINCT TRIM(g)
+FROM movies m, LATERAL unnest(string_to_array(m.genre, ',')) AS g
+WHERE TRIM(g) NOT IN ('', 'N/A')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO movie_genres (movie_id, genre_id)
+SELECT DISTINCT m.id, gr.id
+FROM movies m, LATERAL unnest(string_to_array(m.genre, ',')) AS g
+JOIN genres gr ON gr.name = TRIM(g)
+ON CONFLICT DO NOTHING;
+
+CREATE TEMP TABLE credit_backfill AS
+SELECT m.id AS movie_id, 'director' AS role, TRIM(regexp_replace(c.name, '\(.*\)', '')) AS name, c.ord - 1 AS billing_order
+FROM movies m, LATERAL unnest(string_to_array(m.director, ',')) WITH ORDINALITY AS c(name, ord)
+UNION ALL
+SELECT m.id, 'writer', TRIM(regexp_replace(c.name, '\(.*\)', '')), c.ord - 1
+FROM movies m, LATERAL unnest(string_to_array(m.writer, ',')) WITH ORDINALITY AS c(name, ord)
+UNION ALL
+SELECT m.id, 'actor', TRIM(regexp_replace(c.name, '\(.*\)', '')), c.ord - 1
+FROM movies m, LATERAL unnest(string_to_array(m.actors, ',')) WITH ORDINALITY AS c(name, ord);
+
+DELETE FROM credit_backfill WHERE name IN ('', 'N/A');
+
+INSERT INTO people (name)
+SELECT DISTINCT name FROM credit_backfill
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO movie_credits (movie_id, person_id, role, billing_order)
+SELECT cb.movie_id, p.id, cb.role, MIN(cb.billing_order)
+FROM credit_backfill cb
+JOIN people p ON p.name = cb.name
+GROUP BY cb.movie_id, p.id, cb.role
+ON CONFLICT DO NOTHING;
+
+DROP TABLE credit_backfill;
@@ -0,0 +1,12 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE movies
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(director, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(actors, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(plot, '')), 'C')
+    ) STORED;
+
+CREATE INDEX idx_movies_search_vector ON movies USING GIN (search_vector);
+CREATE INDEX idx_movies_title_trgm ON movies USING GIN (title gin_trgm_ops);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_movies_refreshed_at;
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS refreshed_at,
+    DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,13 @@
+ALTER TABLE movies
+    ADD COLUMN updated_at   TIMESTAMPTZ,
+    ADD COLUMN refreshed_at TIMESTAMPTZ;
+
+UPDATE movies SET updated_at = created_at, refreshed_at = created_at;
+
+ALTER TABLE movies
+    ALTER COLUMN updated_at SET NOT NULL,
+    ALTER COLUMN updated_at SET DEFAULT NOW(),
+    ALTER COLUMN refreshed_at SET NOT NULL,
+    ALTER COLUMN refreshed_at SET DEFAULT NOW();
+
+CREATE INDEX idx_movies_refreshed_at ON movies(refreshed_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS episode_progress;
+DROP TABLE IF EXISTS episodes;
+ALTER TABLE movies DROP COLUMN IF EXISTS total_seasons;
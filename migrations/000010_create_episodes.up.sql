@@ -0,0 +1,22 @@
+ALTER TABLE movies ADD COLUMN total_seasons INTEGER;
+
+CREATE TABLE episodes (
+    id          UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    series_id   UUID         NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    season      INTEGER      NOT NULL,
+    episode     INTEGER      NOT NULL,
+    imdb_id     VARCHAR(20),
+    title       VARCHAR(255) NOT NULL,
+    released    DATE,
+    imdb_rating VARCHAR(10),
+    CONSTRAINT uq_episodes_series_season_episode UNIQUE (series_id, season, episode)
+);
+
+CREATE TABLE episode_progress (
+    watchlist_id UUID        NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+    episode_id   UUID        NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    watched_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (watchlist_id, episode_id)
+);
+
+CREATE INDEX idx_episode_progress_episode_id ON episode_progress(episode_id);
@@ -0,0 +1 @@
+CREATE INDEX idx_movies_created_at ON movies(created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_watchlists_added_at;
+DROP INDEX IF EXISTS idx_ratings_updated_at;
@@ -0,0 +1,2 @@
+CREATE INDEX idx_ratings_updated_at ON ratings(updated_at);
+CREATE INDEX idx_watchlists_added_at ON watchlists(added_at);
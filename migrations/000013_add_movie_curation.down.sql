@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS movie_audit_log;
+DROP TABLE IF EXISTS movie_aliases;
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS locked_fields,
+    DROP COLUMN IF EXISTS hidden;
@@ -0,0 +1,28 @@
+ALTER TABLE movies
+    ADD COLUMN hidden        BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN locked_fields TEXT[]  NOT NULL DEFAULT '{}';
+
+-- Old IMDb IDs of movies merged into another record, so lookups by the old
+-- ID resolve to the surviving movie instead of re-fetching a duplicate.
+CREATE TABLE movie_aliases (
+    imdb_id  VARCHAR(20) PRIMARY KEY,
+    movie_id UUID        NOT NULL REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_movie_aliases_movie_id ON movie_aliases(movie_id);
+
+-- movie_id has no foreign key so history survives merges.
+CREATE TABLE movie_audit_log (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    movie_id   UUID        NOT NULL,
+    imdb_id    VARCHAR(20) NOT NULL,
+    admin_id   UUID        REFERENCES users(id) ON DELETE SET NULL,
+    action     VARCHAR(20) NOT NULL,
+    changes    JSONB       NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_movie_audit_log_action
+        CHECK (action IN ('edit', 'lock', 'hide', 'unhide', 'merge'))
+);
+
+CREATE INDEX idx_movie_audit_log_movie_id ON movie_audit_log(movie_id, created_at DESC);
@@ -0,0 +1,9 @@
+-- Only the default list survives the one-entry-per-user constraint.
+DELETE FROM watchlists w
+USING user_lists l
+WHERE l.id = w.list_id AND NOT l.is_default;
+
+DROP INDEX IF EXISTS uq_watchlist_list_movie;
+ALTER TABLE watchlists ADD CONSTRAINT uq_watchlist_user_movie UNIQUE (user_id, movie_id);
+ALTER TABLE watchlists DROP COLUMN IF EXISTS list_id;
+DROP TABLE IF EXISTS user_lists;
@@ -0,0 +1,30 @@
+-- Named watchlists. Every user has at most one default list, which backs the
+-- original /watchlist routes.
+CREATE TABLE user_lists (
+    id         UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name       VARCHAR(100) NOT NULL,
+    position   INTEGER      NOT NULL DEFAULT 0,
+    is_default BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_user_lists_user_name ON user_lists(user_id, lower(name));
+CREATE UNIQUE INDEX uq_user_lists_default   ON user_lists(user_id) WHERE is_default;
+
+-- Move existing entries into a default list per user.
+INSERT INTO user_lists (user_id, name, is_default)
+SELECT DISTINCT user_id, 'Watchlist', TRUE FROM watchlists;
+
+ALTER TABLE watchlists ADD COLUMN list_id UUID REFERENCES user_lists(id) ON DELETE CASCADE;
+
+UPDATE watchlists w
+SET list_id = l.id
+FROM user_lists l
+WHERE l.user_id = w.user_id AND l.is_default;
+
+ALTER TABLE watchlists ALTER COLUMN list_id SET NOT NULL;
+
+-- A movie may now sit on several lists, but only once per list.
+ALTER TABLE watchlists DROP CONSTRAINT uq_watchlist_user_movie;
+CREATE UNIQUE INDEX uq_watchlist_list_movie ON watchlists(list_id, movie_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_watchlists_list_position;
+ALTER TABLE watchlists
+    DROP COLUMN IF EXISTS note,
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS position;
@@ -0,0 +1,16 @@
+ALTER TABLE watchlists
+    ADD COLUMN position DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN priority SMALLINT         NOT NULL DEFAULT 0
+        CONSTRAINT chk_watchlist_priority CHECK (priority BETWEEN 0 AND 3),
+    ADD COLUMN note     TEXT             NOT NULL DEFAULT '';
+
+-- Start every list's manual order from the newest-first order it had before.
+UPDATE watchlists w
+SET position = r.rn
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY list_id ORDER BY added_at DESC, id) AS rn
+    FROM watchlists
+) r
+WHERE w.id = r.id;
+
+CREATE INDEX idx_watchlists_list_position ON watchlists(list_id, position);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS rating_tags;
+DROP TABLE IF EXISTS watchlist_tags;
+DROP TABLE IF EXISTS user_tags;
@@ -0,0 +1,24 @@
+-- Personal labels. Names are stored normalized (trimmed, lower case).
+CREATE TABLE user_tags (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name       VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_user_tags_user_name UNIQUE (user_id, name)
+);
+
+CREATE TABLE watchlist_tags (
+    watchlist_id UUID NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+    tag_id       UUID NOT NULL REFERENCES user_tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (watchlist_id, tag_id)
+);
+
+CREATE TABLE rating_tags (
+    rating_id UUID NOT NULL REFERENCES ratings(id) ON DELETE CASCADE,
+    tag_id    UUID NOT NULL REFERENCES user_tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (rating_id, tag_id)
+);
+
+CREATE INDEX idx_watchlist_tags_tag_id ON watchlist_tags(tag_id);
+CREATE INDEX idx_rating_tags_tag_id    ON rating_tags(tag_id);
@@ -0,0 +1,17 @@
+-- One row per viewing, so rewatches are kept. watchlist_id points at the
+-- entry whose move to 'watched' logged the event, if any.
+CREATE TABLE watch_events (
+    id           UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    movie_id     UUID         NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    watchlist_id UUID         REFERENCES watchlists(id) ON DELETE SET NULL,
+    watched_at   TIMESTAMPTZ  NOT NULL,
+    rating       SMALLINT
+        CONSTRAINT chk_watch_events_rating CHECK (rating BETWEEN 1 AND 10),
+    venue        VARCHAR(100) NOT NULL DEFAULT '',
+    companions   TEXT[]       NOT NULL DEFAULT '{}',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_watch_events_user_watched_at ON watch_events(user_id, watched_at DESC, id DESC);
+CREATE INDEX idx_watch_events_movie_id        ON watch_events(movie_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_movies_refresh_order;
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS refresh_attempted_at;
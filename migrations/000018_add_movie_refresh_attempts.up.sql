@@ -0,0 +1,4 @@
+ALTER TABLE movies
+    ADD COLUMN refresh_attempted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_movies_refresh_order ON movies ((COALESCE(refresh_attempted_at, refreshed_at)));
@@ -0,0 +1,18 @@
+// Package blobstore stores opaque binary objects by key.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by Get when no object exists for the key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a minimal key/value store for binary objects. Keys are
+// slash-separated paths such as "posters/tt0111161/w300.jpg".
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, r io.Reader) error
+}
@@ -0,0 +1,120 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the circuit breaker state.
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+// Breaker is a consecutive-failure circuit breaker. After `threshold` failures
+// in a row it opens and rejects calls for `cooldown`; it then lets a single
+// trial call through (half-open) and closes again if that call succeeds.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     State
+	failures  int
+	openedAt  time.Time
+	trialSent bool
+}
+
+// NewBreaker creates a closed breaker. A threshold <= 0 disables it.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     StateClosed,
+	}
+}
+
+// Allow reports whether a call may proceed.
+func (b *Breaker) Allow() bool {
+	ok, _ := b.acquire()
+	return ok
+}
+
+// acquire is Allow that also reports whether the call took the half-open
+// trial slot, so the caller can release it with Cancel if it is abandoned.
+func (b *Breaker) acquire() (ok, trial bool) {
+	if b.threshold <= 0 {
+		return true, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false, false
+		}
+		b.state = StateHalfOpen
+		b.trialSent = true
+		return true, true
+	case StateHalfOpen:
+		if b.trialSent {
+			return false, false
+		}
+		b.trialSent = true
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// Cancel releases the half-open trial slot without recording an outcome. It
+// is used when the trial call was cancelled by its caller, which says nothing
+// about the upstream; without it the breaker would stay half-open with the
+// slot taken and reject every later call.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen {
+		b.trialSent = false
+	}
+}
+
+// Success records a successful call and closes the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = StateClosed
+	b.failures = 0
+	b.trialSent = false
+}
+
+// Failure records a failed call, opening the breaker once the threshold is hit
+// or immediately if the half-open trial failed.
+func (b *Breaker) Failure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = time.Now()
+		b.trialSent = false
+	}
+}
+
+// State returns the current breaker state.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
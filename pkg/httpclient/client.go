@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling upstream while the breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Config controls retries and circuit breaking for a Client.
+type Config struct {
+	Timeout          time.Duration // per-attempt timeout
+	MaxRetries       int           // retries after the first attempt
+	BaseBackoff      time.Duration // backoff before the first retry
+	MaxBackoff       time.Duration // upper bound for a single backoff
+	BreakerThreshold int           // consecutive failures that open the breaker (0 disables)
+	BreakerCooldown  time.Duration // how long the breaker stays open
+}
+
+// Client is an outbound HTTP client that retries 5xx responses and network
+// errors with jittered exponential backoff, guarded by a circuit breaker.
+// Requests are bound to their context: cancellation stops both in-flight
+// attempts and backoff sleeps.
+type Client struct {
+	cfg     Config
+	http    *http.Client
+	breaker *Breaker
+}
+
+func New(cfg Config) *Client {
+	return &Client{
+		cfg:     cfg,
+		http:    &http.Client{Timeout: cfg.Timeout},
+		breaker: NewBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// Get issues a GET request bound to ctx.
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Do sends req, retrying on network errors and 5xx responses. Only requests
+// without a body are retried, since a consumed body cannot be replayed.
+// Non-5xx responses are returned as-is for the caller to interpret.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	maxAttempts := 1
+	if req.Body == nil || req.Body == http.NoBody {
+		maxAttempts += c.cfg.MaxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+
+		ok, trial := c.breaker.acquire()
+		if !ok {
+			return nil, ErrCircuitOpen
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Caller cancelled; not the upstream's fault. A cancelled
+				// trial must hand its slot back or the breaker never closes.
+				if trial {
+					c.breaker.Cancel()
+				}
+				return nil, ctx.Err()
+			}
+			c.breaker.Failure()
+			lastErr = stripQuery(err)
+			continue
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			c.breaker.Failure()
+			lastErr = fmt.Errorf("upstream returned status %d", resp.StatusCode)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			continue
+		}
+
+		c.breaker.Success()
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("giving up after %d attempt(s): %w", maxAttempts, lastErr)
+}
+
+// BreakerState exposes the circuit breaker state for health reporting.
+func (c *Client) BreakerState() State {
+	return c.breaker.State()
+}
+
+// backoff returns a full-jitter exponential delay for the given retry number.
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := c.cfg.BaseBackoff << (attempt - 1)
+	if ceiling <= 0 || ceiling > c.cfg.MaxBackoff {
+		ceiling = c.cfg.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling)) + 1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// stripQuery removes the query string from a *url.Error so API keys passed as
+// query parameters never end up in logs or error messages.
+func stripQuery(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		if i := strings.IndexByte(ue.URL, '?'); i >= 0 {
+			ue.URL = ue.URL[:i]
+		}
+	}
+	return err
+}
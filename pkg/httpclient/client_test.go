@@ -0,0 +1,312 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fault is what the stand-in upstream does with one request.
+type fault int
+
+const (
+	faultNone fault = iota // 200 OK
+	fault500               // 500 Internal Server Error
+	faultDrop              // close the connection without a response
+	faultHang              // block until the client gives up
+)
+
+// upstream is an httptest stand-in that replays a queue of faults, one per
+// request, and answers 200 once the queue is empty.
+type upstream struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	faults []fault
+	hits   int
+}
+
+func newUpstream(t *testing.T, faults ...fault) *upstream {
+	t.Helper()
+	u := &upstream{faults: faults}
+	u.Server = httptest.NewServer(http.HandlerFunc(u.serve))
+	t.Cleanup(u.Close)
+	return u
+}
+
+func (u *upstream) serve(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	u.hits++
+	f := faultNone
+	if len(u.faults) > 0 {
+		f, u.faults = u.faults[0], u.faults[1:]
+	}
+	u.mu.Unlock()
+
+	switch f {
+	case fault500:
+		w.WriteHeader(http.StatusInternalServerError)
+	case faultDrop:
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	case faultHang:
+		<-r.Context().Done()
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (u *upstream) hitCount() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.hits
+}
+
+func testClient(cfg Config) *Client {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 2 * time.Second
+	}
+	if cfg.BaseBackoff == 0 {
+		cfg.BaseBackoff = time.Millisecond
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = 5 * time.Millisecond
+	}
+	return New(cfg)
+}
+
+func get(t *testing.T, c *Client, url string) (*http.Response, error) {
+	t.Helper()
+	resp, err := c.Get(context.Background(), url)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestDoRetriesServerErrors(t *testing.T) {
+	u := newUpstream(t, fault500, fault500)
+	c := testClient(Config{MaxRetries: 2})
+
+	resp, err := get(t, c, u.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := u.hitCount(); got != 3 {
+		t.Fatalf("hits = %d, want 3", got)
+	}
+}
+
+func TestDoGivesUpAfterMaxRetries(t *testing.T) {
+	u := newUpstream(t, fault500, fault500, fault500, fault500)
+	c := testClient(Config{MaxRetries: 2})
+
+	if _, err := get(t, c, u.URL); err == nil {
+		t.Fatal("Get succeeded, want error after retries are exhausted")
+	}
+	if got := u.hitCount(); got != 3 {
+		t.Fatalf("hits = %d, want 3", got)
+	}
+}
+
+func TestDoRetriesTransportErrors(t *testing.T) {
+	u := newUpstream(t, faultDrop)
+	c := testClient(Config{MaxRetries: 1})
+
+	resp, err := get(t, c, u.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := u.hitCount(); got != 2 {
+		t.Fatalf("hits = %d, want 2", got)
+	}
+}
+
+func TestDoDoesNotRetryRequestWithBody(t *testing.T) {
+	u := newUpstream(t, fault500, fault500, fault500)
+	c := testClient(Config{MaxRetries: 2})
+
+	req, err := http.NewRequest(http.MethodPost, u.URL, strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Do(req); err == nil {
+		t.Fatal("Do succeeded, want error")
+	}
+	if got := u.hitCount(); got != 1 {
+		t.Fatalf("hits = %d, want 1", got)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	c := New(Config{BaseBackoff: 100 * time.Millisecond, MaxBackoff: 250 * time.Millisecond})
+
+	for attempt := 1; attempt <= 70; attempt++ {
+		for i := 0; i < 50; i++ {
+			d := c.backoff(attempt)
+			if d <= 0 || d > 250*time.Millisecond {
+				t.Fatalf("backoff(%d) = %v, want (0, 250ms]", attempt, d)
+			}
+			if attempt == 1 && d > 100*time.Millisecond {
+				t.Fatalf("backoff(1) = %v, want at most the base backoff", d)
+			}
+		}
+	}
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	u := newUpstream(t, fault500, fault500, fault500)
+	c := testClient(Config{BreakerThreshold: 3, BreakerCooldown: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		if _, err := get(t, c, u.URL); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: err = %v, want upstream failure", i+1, err)
+		}
+	}
+	if s := c.BreakerState(); s != StateOpen {
+		t.Fatalf("state = %s, want open", s)
+	}
+
+	if _, err := get(t, c, u.URL); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if got := u.hitCount(); got != 3 {
+		t.Fatalf("hits = %d, want 3 (open breaker must not call upstream)", got)
+	}
+}
+
+func TestBreakerHalfOpensAfterCooldown(t *testing.T) {
+	u := newUpstream(t, fault500)
+	c := testClient(Config{BreakerThreshold: 1, BreakerCooldown: 30 * time.Millisecond})
+
+	get(t, c, u.URL)
+	if s := c.BreakerState(); s != StateOpen {
+		t.Fatalf("state = %s, want open", s)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if s := c.BreakerState(); s != StateHalfOpen {
+		t.Fatalf("state after cooldown = %s, want half_open", s)
+	}
+
+	if _, err := get(t, c, u.URL); err != nil {
+		t.Fatalf("trial Get: %v", err)
+	}
+	if s := c.BreakerState(); s != StateClosed {
+		t.Fatalf("state after successful trial = %s, want closed", s)
+	}
+}
+
+func TestBreakerReopensOnFailedTrial(t *testing.T) {
+	u := newUpstream(t, fault500, fault500)
+	c := testClient(Config{BreakerThreshold: 1, BreakerCooldown: 30 * time.Millisecond})
+
+	get(t, c, u.URL)
+	time.Sleep(40 * time.Millisecond)
+
+	if _, err := get(t, c, u.URL); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("trial err = %v, want upstream failure", err)
+	}
+	if s := c.BreakerState(); s != StateOpen {
+		t.Fatalf("state after failed trial = %s, want open", s)
+	}
+}
+
+func TestBreakerAllowsSingleTrial(t *testing.T) {
+	u := newUpstream(t, fault500, faultHang)
+	c := testClient(Config{BreakerThreshold: 1, BreakerCooldown: 30 * time.Millisecond})
+
+	get(t, c, u.URL)
+	time.Sleep(40 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	trialDone := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.URL, nil)
+		_, err := c.Do(req)
+		trialDone <- err
+	}()
+
+	// Wait until the trial is parked upstream, then try to pass alongside it.
+	deadline := time.Now().Add(time.Second)
+	for u.hitCount() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("trial request never reached upstream")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := get(t, c, u.URL); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("concurrent call %d: err = %v, want ErrCircuitOpen", i+1, err)
+		}
+	}
+	if got := u.hitCount(); got != 2 {
+		t.Fatalf("hits = %d, want 2", got)
+	}
+
+	cancel()
+	<-trialDone
+}
+
+func TestCancelledTrialDoesNotWedgeBreaker(t *testing.T) {
+	u := newUpstream(t, fault500, faultHang)
+	c := testClient(Config{BreakerThreshold: 1, BreakerCooldown: 30 * time.Millisecond})
+
+	get(t, c, u.URL)
+	time.Sleep(40 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.URL, nil)
+	if _, err := c.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("trial err = %v, want context.DeadlineExceeded", err)
+	}
+
+	// The abandoned trial says nothing about upstream: the next call must be
+	// let through as a fresh trial rather than rejected forever.
+	if _, err := get(t, c, u.URL); err != nil {
+		t.Fatalf("Get after cancelled trial: %v", err)
+	}
+	if s := c.BreakerState(); s != StateClosed {
+		t.Fatalf("state = %s, want closed", s)
+	}
+
+	// A cancelled call outside half-open leaves the breaker alone.
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	req2, _ := http.NewRequestWithContext(ctx2, http.MethodGet, u.URL, nil)
+	if _, err := c.Do(req2); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if s := c.BreakerState(); s != StateClosed {
+		t.Fatalf("state = %s, want closed", s)
+	}
+}
+
+func TestZeroThresholdDisablesBreaker(t *testing.T) {
+	u := newUpstream(t, fault500, fault500, fault500, fault500, fault500)
+	c := testClient(Config{BreakerThreshold: 0})
+
+	for i := 0; i < 5; i++ {
+		if _, err := get(t, c, u.URL); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: breaker opened with threshold 0", i+1)
+		}
+	}
+	if got := u.hitCount(); got != 5 {
+		t.Fatalf("hits = %d, want 5", got)
+	}
+}
@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"reflect"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -22,13 +24,15 @@ func FormatValidationErrors(err error) []string {
 			case "email":
 				errors = append(errors, e.Field()+" must be a valid email address")
 			case "min":
-				errors = append(errors, e.Field()+" must be at least "+e.Param()+" characters")
+				errors = append(errors, e.Field()+" must be at least "+e.Param()+" "+lengthUnit(e))
 			case "max":
-				errors = append(errors, e.Field()+" must be at most "+e.Param()+" characters")
+				errors = append(errors, e.Field()+" must be at most "+e.Param()+" "+lengthUnit(e))
 			case "gte":
 				errors = append(errors, e.Field()+" must be greater than or equal to "+e.Param())
 			case "lte":
 				errors = append(errors, e.Field()+" must be less than or equal to "+e.Param())
+			case "oneof":
+				errors = append(errors, e.Field()+" must be one of: "+e.Param())
 			default:
 				errors = append(errors, e.Field()+" failed validation: "+e.Tag())
 			}
@@ -36,3 +40,13 @@ func FormatValidationErrors(err error) []string {
 	}
 	return errors
 }
+
+// lengthUnit names what a min/max length constraint counts.
+func lengthUnit(e validator.FieldError) string {
+	switch e.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	default:
+		return "characters"
+	}
+}
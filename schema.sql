@@ -13,11 +13,15 @@ CREATE TABLE IF NOT EXISTS users (
     username      VARCHAR(50) NOT NULL,
     email         VARCHAR(255) NOT NULL,
     password_hash VARCHAR(255) NOT NULL,
+    role          VARCHAR(20) NOT NULL DEFAULT 'user',
     created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 
     CONSTRAINT uq_users_username UNIQUE (username),
-    CONSTRAINT uq_users_email    UNIQUE (email)
+    CONSTRAINT uq_users_email    UNIQUE (email),
+
+    -- Role must be one of the allowed values
+    CONSTRAINT chk_users_role CHECK (role IN ('user', 'admin'))
 );
 
 -- Indexes
@@ -38,6 +42,16 @@ CREATE TABLE IF NOT EXISTS movies (
     plot        TEXT,
     poster_url  TEXT,
     imdb_rating VARCHAR(10),
+    rated           VARCHAR(20),
+    released        DATE,
+    runtime_minutes INTEGER,
+    writer          TEXT,
+    language        VARCHAR(255),
+    country         VARCHAR(255),
+    awards          TEXT,
+    type            VARCHAR(20),
+    release_year    INTEGER,
+    imdb_score      NUMERIC(3,1),
     created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
 
     CONSTRAINT uq_movies_imdb_id UNIQUE (imdb_id)
@@ -47,6 +61,10 @@ CREATE TABLE IF NOT EXISTS movies (
 CREATE INDEX IF NOT EXISTS idx_movies_imdb_id ON movies(imdb_id);
 CREATE INDEX IF NOT EXISTS idx_movies_genre   ON movies(genre);
 CREATE INDEX IF NOT EXISTS idx_movies_title   ON movies(title);
+CREATE INDEX IF NOT EXISTS idx_movies_runtime_minutes ON movies(runtime_minutes);
+CREATE INDEX IF NOT EXISTS idx_movies_released        ON movies(released);
+CREATE INDEX IF NOT EXISTS idx_movies_release_year    ON movies(release_year);
+CREATE INDEX IF NOT EXISTS idx_movies_imdb_score      ON movies(imdb_score);
 
 -- =============================================================
 -- 3. WATCHLISTS TABLE
@@ -129,3 +147,249 @@ CREATE TRIGGER trg_ratings_updated_at
     BEFORE UPDATE ON ratings
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
+
+-- =============================================================
+-- 6. GENRES, PEOPLE & MOVIE CREDITS (normalized from movies)
+-- =============================================================
+CREATE TABLE IF NOT EXISTS genres (
+    id   SERIAL       PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+
+    CONSTRAINT uq_genres_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS movie_genres (
+    movie_id UUID    NOT NULL,
+    genre_id INTEGER NOT NULL,
+
+    PRIMARY KEY (movie_id, genre_id),
+    CONSTRAINT fk_movie_genres_movie
+        FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    CONSTRAINT fk_movie_genres_genre
+        FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_genres_genre_id ON movie_genres(genre_id);
+
+CREATE TABLE IF NOT EXISTS people (
+    id   UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+
+    CONSTRAINT uq_people_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS movie_credits (
+    movie_id      UUID        NOT NULL,
+    person_id     UUID        NOT NULL,
+    role          VARCHAR(20) NOT NULL,
+    billing_order INTEGER     NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (movie_id, person_id, role),
+    CONSTRAINT fk_movie_credits_movie
+        FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    CONSTRAINT fk_movie_credits_person
+        FOREIGN KEY (person_id) REFERENCES people(id) ON DELETE CASCADE,
+
+    -- Role must be one of the allowed values
+    CONSTRAINT chk_movie_credits_role
+        CHECK (role IN ('director', 'writer', 'actor'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_credits_person_id ON movie_credits(person_id);
+
+-- =============================================================
+-- 7. LOCAL CATALOG SEARCH (full-text + trigram)
+-- =============================================================
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(director, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(actors, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(plot, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_movies_title_trgm    ON movies USING GIN (title gin_trgm_ops);
+
+-- =============================================================
+-- 8. MOVIE METADATA REFRESH
+-- =============================================================
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS refreshed_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS refresh_attempted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_movies_refreshed_at  ON movies(refreshed_at);
+CREATE INDEX IF NOT EXISTS idx_movies_refresh_order ON movies((COALESCE(refresh_attempted_at, refreshed_at)));
+
+-- =============================================================
+-- 9. SERIES EPISODES & WATCH PROGRESS
+-- =============================================================
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS total_seasons INTEGER;
+
+CREATE TABLE IF NOT EXISTS episodes (
+    id          UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    series_id   UUID         NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    season      INTEGER      NOT NULL,
+    episode     INTEGER      NOT NULL,
+    imdb_id     VARCHAR(20),
+    title       VARCHAR(255) NOT NULL,
+    released    DATE,
+    imdb_rating VARCHAR(10),
+    CONSTRAINT uq_episodes_series_season_episode UNIQUE (series_id, season, episode)
+);
+
+CREATE TABLE IF NOT EXISTS episode_progress (
+    watchlist_id UUID        NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+    episode_id   UUID        NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    watched_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (watchlist_id, episode_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_episode_progress_episode_id ON episode_progress(episode_id);
+
+-- =============================================================
+-- 10. CATALOG BROWSE
+-- =============================================================
+CREATE INDEX IF NOT EXISTS idx_movies_created_at ON movies(created_at DESC, id DESC);
+
+-- =============================================================
+-- 11. TRENDING & POPULAR
+-- =============================================================
+CREATE INDEX IF NOT EXISTS idx_ratings_updated_at ON ratings(updated_at);
+CREATE INDEX IF NOT EXISTS idx_watchlists_added_at ON watchlists(added_at);
+
+-- =============================================================
+-- 12. ADMIN CURATION (hidden titles, field locks, merges, audit)
+-- =============================================================
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS hidden        BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS locked_fields TEXT[]  NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS movie_aliases (
+    imdb_id  VARCHAR(20) PRIMARY KEY,
+    movie_id UUID        NOT NULL REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_aliases_movie_id ON movie_aliases(movie_id);
+
+CREATE TABLE IF NOT EXISTS movie_audit_log (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    movie_id   UUID        NOT NULL,
+    imdb_id    VARCHAR(20) NOT NULL,
+    admin_id   UUID        REFERENCES users(id) ON DELETE SET NULL,
+    action     VARCHAR(20) NOT NULL,
+    changes    JSONB       NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_movie_audit_log_action
+        CHECK (action IN ('edit', 'lock', 'hide', 'unhide', 'merge'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_audit_log_movie_id ON movie_audit_log(movie_id, created_at DESC);
+
+-- =============================================================
+-- 13. NAMED WATCHLISTS
+-- =============================================================
+CREATE TABLE IF NOT EXISTS user_lists (
+    id         UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name       VARCHAR(100) NOT NULL,
+    position   INTEGER      NOT NULL DEFAULT 0,
+    is_default BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_lists_user_name ON user_lists(user_id, lower(name));
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_lists_default   ON user_lists(user_id) WHERE is_default;
+
+ALTER TABLE watchlists
+    ADD COLUMN IF NOT EXISTS list_id UUID REFERENCES user_lists(id) ON DELETE CASCADE;
+
+-- Move entries without a list into the user's default list.
+INSERT INTO user_lists (user_id, name, is_default)
+SELECT DISTINCT user_id, 'Watchlist', TRUE FROM watchlists WHERE list_id IS NULL
+ON CONFLICT DO NOTHING;
+
+UPDATE watchlists w
+SET list_id = l.id
+FROM user_lists l
+WHERE w.list_id IS NULL AND l.user_id = w.user_id AND l.is_default;
+
+ALTER TABLE watchlists ALTER COLUMN list_id SET NOT NULL;
+ALTER TABLE watchlists DROP CONSTRAINT IF EXISTS uq_watchlist_user_movie;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_watchlist_list_movie ON watchlists(list_id, movie_id);
+
+-- =============================================================
+-- 14. WATCHLIST ORDERING, PRIORITY & NOTES
+-- =============================================================
+ALTER TABLE watchlists
+    ADD COLUMN IF NOT EXISTS position DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS priority SMALLINT         NOT NULL DEFAULT 0
+        CONSTRAINT chk_watchlist_priority CHECK (priority BETWEEN 0 AND 3),
+    ADD COLUMN IF NOT EXISTS note     TEXT             NOT NULL DEFAULT '';
+
+-- Number lists whose entries predate manual ordering newest first, as the
+-- migration does. Lists that already have an order are left alone.
+UPDATE watchlists w
+SET position = r.rn
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY list_id ORDER BY added_at DESC, id) AS rn
+    FROM watchlists
+    WHERE list_id IN (
+        SELECT list_id FROM watchlists
+        GROUP BY list_id
+        HAVING COUNT(*) > 1 AND bool_and(position = 0)
+    )
+) r
+WHERE w.id = r.id;
+
+CREATE INDEX IF NOT EXISTS idx_watchlists_list_position ON watchlists(list_id, position);
+
+-- =============================================================
+-- 15. USER TAGS (personal labels on watchlist entries and ratings)
+-- =============================================================
+CREATE TABLE IF NOT EXISTS user_tags (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name       VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_user_tags_user_name UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS watchlist_tags (
+    watchlist_id UUID NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+    tag_id       UUID NOT NULL REFERENCES user_tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (watchlist_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS rating_tags (
+    rating_id UUID NOT NULL REFERENCES ratings(id) ON DELETE CASCADE,
+    tag_id    UUID NOT NULL REFERENCES user_tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (rating_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_tags_tag_id ON watchlist_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_rating_tags_tag_id    ON rating_tags(tag_id);
+
+-- =============================================================
+-- 16. WATCH HISTORY (one row per viewing, including rewatches)
+-- =============================================================
+CREATE TABLE IF NOT EXISTS watch_events (
+    id           UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    movie_id     UUID         NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    watchlist_id UUID         REFERENCES watchlists(id) ON DELETE SET NULL,
+    watched_at   TIMESTAMPTZ  NOT NULL,
+    rating       SMALLINT
+        CONSTRAINT chk_watch_events_rating CHECK (rating BETWEEN 1 AND 10),
+    venue        VARCHAR(100) NOT NULL DEFAULT '',
+    companions   TEXT[]       NOT NULL DEFAULT '{}',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_watch_events_user_watched_at ON watch_events(user_id, watched_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_watch_events_movie_id        ON watch_events(movie_id);